@@ -0,0 +1,5 @@
+data "bitrise_addons" "all" {}
+
+output "addon_plans" {
+  value = { for addon in data.bitrise_addons.all.addons : addon.id => addon.plans[*].id }
+}
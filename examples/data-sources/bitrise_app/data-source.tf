@@ -0,0 +1,8 @@
+data "bitrise_app" "by_slug" {
+  slug = "a1b2c3d4e5f6a7b8"
+}
+
+data "bitrise_app" "by_title" {
+  title             = "my-flutter-app"
+  organization_slug = "cf38e3d194d03fa2"
+}
@@ -0,0 +1,10 @@
+data "bitrise_apps" "flutter" {
+  organization_slug = "cf38e3d194d03fa2"
+  project_type      = "flutter"
+  title_regex       = "^nates-"
+  is_disabled       = false
+}
+
+output "flutter_app_slugs" {
+  value = toset(data.bitrise_apps.flutter.apps[*].slug)
+}
@@ -0,0 +1,15 @@
+data "bitrise_latest_build" "release" {
+  app_slug = bitrise_app.app.id
+  branch   = "main"
+  workflow = "release"
+}
+
+data "bitrise_build_artifacts" "ipa" {
+  app_slug      = bitrise_app.app.id
+  build_slug    = data.bitrise_latest_build.release.id
+  artifact_type = "ios-ipa"
+}
+
+output "ipa_install_page" {
+  value = data.bitrise_build_artifacts.ipa.artifacts[0].public_install_page_url
+}
@@ -0,0 +1,17 @@
+data "bitrise_latest_build" "smoke" {
+  app_slug = bitrise_app.app.id
+  workflow = "smoke"
+  status   = "error"
+}
+
+data "bitrise_build_log" "smoke" {
+  app_slug       = bitrise_app.app.id
+  build_slug     = data.bitrise_latest_build.smoke.id
+  tail_lines     = 200
+  redact_secrets = true
+}
+
+output "smoke_failure_log" {
+  value     = data.bitrise_build_log.smoke.content
+  sensitive = true
+}
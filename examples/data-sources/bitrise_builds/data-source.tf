@@ -0,0 +1,11 @@
+data "bitrise_builds" "release" {
+  app_slug = bitrise_app.app.id
+  branch   = "main"
+  workflow = "release"
+  status   = "success"
+  limit    = 10
+}
+
+output "release_build_numbers" {
+  value = data.bitrise_builds.release.builds[*].build_number
+}
@@ -0,0 +1,9 @@
+data "bitrise_latest_build" "release" {
+  app_slug = bitrise_app.app.id
+  branch   = "main"
+  workflow = "release"
+}
+
+output "release_build_number" {
+  value = data.bitrise_latest_build.release.build_number
+}
@@ -0,0 +1,5 @@
+data "bitrise_me" "current" {}
+
+output "token_owner" {
+  value = data.bitrise_me.current.username
+}
@@ -0,0 +1,8 @@
+data "bitrise_organization" "mobile" {
+  name = "PG Mobile"
+}
+
+resource "bitrise_app" "app" {
+  organization_slug = data.bitrise_organization.mobile.slug
+  # ...
+}
@@ -0,0 +1,5 @@
+data "bitrise_organizations" "all" {}
+
+output "organization_slugs" {
+  value = { for org in data.bitrise_organizations.all.organizations : org.name => org.slug }
+}
@@ -0,0 +1,7 @@
+data "bitrise_test_devices" "mobile" {
+  organization_slug = data.bitrise_organization.mobile.slug
+}
+
+output "registered_udids" {
+  value = data.bitrise_test_devices.mobile.devices[*].udid
+}
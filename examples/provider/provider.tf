@@ -1,3 +1,10 @@
-provider "scaffolding" {
-  # example configuration here
+variable "bitrise_token" {
+  type      = string
+  sensitive = true
+}
+
+provider "bitrise" {
+  # token can also be set with the BITRISE_TOKEN environment variable
+  token          = var.bitrise_token
+  validate_token = true
 }
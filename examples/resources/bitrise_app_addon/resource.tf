@@ -0,0 +1,5 @@
+resource "bitrise_app_addon" "ship" {
+  app_slug = bitrise_app.app.id
+  addon_id = "addons-ship"
+  plan     = "pro"
+}
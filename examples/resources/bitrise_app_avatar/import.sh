@@ -0,0 +1 @@
+terraform import bitrise_app_avatar.app a1b2c3d4e5f6a7b8
@@ -0,0 +1,4 @@
+resource "bitrise_app_avatar" "app" {
+  app_slug = bitrise_app.app.id
+  source   = "${path.module}/avatar.png"
+}
@@ -0,0 +1,17 @@
+resource "time_rotating" "trigger_token" {
+  rotation_days = 90
+}
+
+resource "bitrise_app_build_trigger_token" "app" {
+  app_slug = bitrise_app.app.id
+
+  rotation_triggers = {
+    rotated_on = time_rotating.trigger_token.id
+  }
+}
+
+resource "github_actions_secret" "bitrise_trigger_token" {
+  repository      = "nates_bitrise_provider_app"
+  secret_name     = "BITRISE_BUILD_TRIGGER_TOKEN"
+  plaintext_value = bitrise_app_build_trigger_token.app.token
+}
@@ -0,0 +1 @@
+terraform import bitrise_app_group_access.flutter_developers a1b2c3d4e5f6a7b8/member/9a8b7c6d5e4f3a2b
@@ -0,0 +1,10 @@
+data "bitrise_app" "app" {
+  title             = "nates-cool-flutter-again"
+  organization_slug = data.bitrise_organization.mobile.slug
+}
+
+resource "bitrise_app_group_access" "flutter_developers" {
+  app_slug   = data.bitrise_app.app.slug
+  group_slug = bitrise_organization_group.flutter.id
+  role       = "member"
+}
@@ -0,0 +1 @@
+terraform import bitrise_group_membership.nate cf38e3d194d03fa2/9a8b7c6d5e4f3a2b/1a2b3c4d5e6f7a8b
@@ -0,0 +1,5 @@
+resource "bitrise_group_membership" "nate" {
+  organization_slug = bitrise_organization_group.flutter.organization_slug
+  group_slug        = bitrise_organization_group.flutter.id
+  user_slug         = bitrise_organization_member.nate.user_slug
+}
@@ -0,0 +1 @@
+terraform import bitrise_organization_group.flutter cf38e3d194d03fa2/9a8b7c6d5e4f3a2b
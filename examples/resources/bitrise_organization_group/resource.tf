@@ -0,0 +1,4 @@
+resource "bitrise_organization_group" "flutter" {
+  organization_slug = data.bitrise_organization.mobile.slug
+  name              = "Flutter developers"
+}
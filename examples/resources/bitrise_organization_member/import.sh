@@ -0,0 +1 @@
+terraform import bitrise_organization_member.nate cf38e3d194d03fa2/nate@example.com
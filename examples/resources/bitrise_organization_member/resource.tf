@@ -0,0 +1,5 @@
+resource "bitrise_organization_member" "nate" {
+  organization_slug = data.bitrise_organization.mobile.slug
+  email             = "nate@example.com"
+  role              = "member"
+}
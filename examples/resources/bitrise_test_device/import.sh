@@ -0,0 +1,2 @@
+terraform import bitrise_test_device.nates_iphone 1a2b3c4d5e6f7a8b
+terraform import bitrise_test_device.qa_ipad cf38e3d194d03fa2/9a8b7c6d5e4f3a2b
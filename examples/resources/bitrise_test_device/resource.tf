@@ -0,0 +1,12 @@
+resource "bitrise_test_device" "nates_iphone" {
+  udid        = "00008030-001A35E11A88003A"
+  device_type = "ios"
+  name        = "Nate's iPhone"
+}
+
+resource "bitrise_test_device" "qa_ipad" {
+  organization_slug = data.bitrise_organization.mobile.slug
+  udid              = "0123456789abcdef0123456789abcdef01234567"
+  device_type       = "ios"
+  name              = "QA iPad"
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AddonsDataSource{}
+
+func NewAddonsDataSource() datasource.DataSource {
+	return &AddonsDataSource{}
+}
+
+// AddonsDataSource defines the data source implementation.
+type AddonsDataSource struct {
+	client *BitriseClient
+}
+
+type Addon struct {
+	Id               string      `json:"id"`
+	Title            string      `json:"title"`
+	Summary          string      `json:"summary"`
+	IsBeta           bool        `json:"is_beta"`
+	DocumentationUrl string      `json:"documentation_url"`
+	Plans            []AddonPlan `json:"plans"`
+}
+
+type AddonPlan struct {
+	Id       string   `json:"id"`
+	Name     string   `json:"name"`
+	Price    int64    `json:"price"`
+	Features []string `json:"features"`
+}
+
+type addonListResponse struct {
+	Data []Addon `json:"data"`
+}
+
+// AddonsDataSourceModel describes the data source data model.
+type AddonsDataSourceModel struct {
+	Id     types.String      `tfsdk:"id"`
+	Addons []AddonsItemModel `tfsdk:"addons"`
+}
+
+// AddonsItemModel describes a single add-on of the add-ons data source.
+type AddonsItemModel struct {
+	Id               types.String     `tfsdk:"id"`
+	Title            types.String     `tfsdk:"title"`
+	Summary          types.String     `tfsdk:"summary"`
+	IsBeta           types.Bool       `tfsdk:"is_beta"`
+	DocumentationUrl types.String     `tfsdk:"documentation_url"`
+	Plans            []AddonPlanModel `tfsdk:"plans"`
+}
+
+// AddonPlanModel describes a plan of an add-on.
+type AddonPlanModel struct {
+	Id       types.String   `tfsdk:"id"`
+	Name     types.String   `tfsdk:"name"`
+	Price    types.Int64    `tfsdk:"price"`
+	Features []types.String `tfsdk:"features"`
+}
+
+func (d *AddonsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_addons"
+}
+
+func (d *AddonsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the add-ons available to apps, with their plans.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the listing",
+			},
+			"addons": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Available add-ons",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the add-on",
+						},
+						"title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Title of the add-on",
+						},
+						"summary": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Short description of the add-on",
+						},
+						"is_beta": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the add-on is in beta",
+						},
+						"documentation_url": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "URL of the add-on documentation",
+						},
+						"plans": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Plans of the add-on",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "ID of the plan",
+									},
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Name of the plan",
+									},
+									"price": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "Monthly price of the plan in cents",
+									},
+									"features": schema.ListAttribute{
+										Computed:            true,
+										ElementType:         types.StringType,
+										MarkdownDescription: "Features included in the plan",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AddonsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AddonsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AddonsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := addonListResponse{}
+	err := d.client.do(ctx, http.MethodGet, "/addons", nil, nil, &respStruct)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list add-ons, got error: %s", err))
+		return
+	}
+
+	data.Addons = []AddonsItemModel{}
+	for _, addon := range respStruct.Data {
+		plans := []AddonPlanModel{}
+		for _, plan := range addon.Plans {
+			features := []types.String{}
+			for _, feature := range plan.Features {
+				features = append(features, types.StringValue(feature))
+			}
+			plans = append(plans, AddonPlanModel{
+				Id:       types.StringValue(plan.Id),
+				Name:     types.StringValue(plan.Name),
+				Price:    types.Int64Value(plan.Price),
+				Features: features,
+			})
+		}
+		data.Addons = append(data.Addons, AddonsItemModel{
+			Id:               types.StringValue(addon.Id),
+			Title:            types.StringValue(addon.Title),
+			Summary:          types.StringValue(addon.Summary),
+			IsBeta:           types.BoolValue(addon.IsBeta),
+			DocumentationUrl: types.StringValue(addon.DocumentationUrl),
+			Plans:            plans,
+		})
+	}
+	data.Id = types.StringValue("addons")
+
+	tflog.Trace(ctx, "read an addons data source", map[string]interface{}{"count": len(data.Addons)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
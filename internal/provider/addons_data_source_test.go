@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAddonsDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "bitrise_addons" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_addons.test", "addons.#", "2"),
+					resource.TestCheckResourceAttr("data.bitrise_addons.test", "addons.0.id", "addons-ship"),
+					resource.TestCheckResourceAttr("data.bitrise_addons.test", "addons.0.plans.#", "2"),
+					resource.TestCheckResourceAttr("data.bitrise_addons.test", "addons.0.plans.1.id", "pro"),
+					resource.TestCheckResourceAttr("data.bitrise_addons.test", "addons.0.plans.1.price", "4900"),
+					resource.TestCheckResourceAttr("data.bitrise_addons.test", "addons.0.plans.1.features.#", "2"),
+					resource.TestCheckResourceAttr("data.bitrise_addons.test", "addons.1.is_beta", "true"),
+				),
+			},
+		},
+	})
+}
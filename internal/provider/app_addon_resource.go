@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Default durations of the bitrise_app_addon operations, overridable with the
+// `timeouts` block. Provisioning an add-on can take a while.
+const (
+	appAddonCreateTimeout = 10 * time.Minute
+	appAddonUpdateTimeout = 10 * time.Minute
+	appAddonDeleteTimeout = 10 * time.Minute
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AppAddonResource{}
+var _ resource.ResourceWithImportState = &AppAddonResource{}
+
+func NewAppAddonResource() resource.Resource {
+	return &AppAddonResource{}
+}
+
+// AppAddonResource defines the resource implementation.
+type AppAddonResource struct {
+	client *BitriseClient
+}
+
+// AppAddon is an add-on provisioned for an app.
+type AppAddon struct {
+	Id        string    `json:"id"`
+	Title     string    `json:"title"`
+	IsEnabled bool      `json:"is_enabled"`
+	Plan      AddonPlan `json:"plan"`
+}
+
+// AppAddonParams is the body of `PUT /apps/{app-slug}/addons/{addon-id}`.
+type AppAddonParams struct {
+	PlanId string `json:"plan_id"`
+}
+
+type appAddonResponse struct {
+	Data AppAddon `json:"data"`
+}
+
+type appAddonListResponse struct {
+	Data []AppAddon `json:"data"`
+}
+
+// AppAddonResourceModel describes the resource data model.
+type AppAddonResourceModel struct {
+	Id       types.String   `tfsdk:"id"`
+	AppSlug  types.String   `tfsdk:"app_slug"`
+	AddonId  types.String   `tfsdk:"addon_id"`
+	Plan     types.String   `tfsdk:"plan"`
+	Title    types.String   `tfsdk:"title"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *AppAddonResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_addon"
+}
+
+func (r *AppAddonResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Provisions an add-on for an app. The available add-ons and plans are listed by the `bitrise_addons` data source. Import with `<app_slug>/<addon_id>`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "`<app_slug>/<addon_id>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG of the app",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"addon_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the add-on",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"plan": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the add-on plan. Changing it switches the plan in place.",
+			},
+			"title": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Title of the add-on",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *AppAddonResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AppAddonResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AppAddonResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, appAddonCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	addon, err := putAppAddon(ctx, r.client, data.AppSlug.ValueString(), data.AddonId.ValueString(), data.Plan.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to provision add-on, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.AppSlug.ValueString() + "/" + data.AddonId.ValueString())
+	data.Title = types.StringValue(addon.Title)
+
+	tflog.Trace(ctx, "provisioned an app addon")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppAddonResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AppAddonResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := appAddonListResponse{}
+	err := r.client.do(ctx, http.MethodGet, appAddonsPath(data.AppSlug.ValueString()), nil, nil, &respStruct)
+	if IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list app add-ons, got error: %s", err))
+		return
+	}
+
+	var addon *AppAddon
+	for i := range respStruct.Data {
+		if respStruct.Data[i].Id == data.AddonId.ValueString() && respStruct.Data[i].IsEnabled {
+			addon = &respStruct.Data[i]
+			break
+		}
+	}
+	if addon == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Plan = types.StringValue(addon.Plan.Id)
+	data.Title = types.StringValue(addon.Title)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppAddonResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *AppAddonResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, appAddonUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	addon, err := putAppAddon(ctx, r.client, data.AppSlug.ValueString(), data.AddonId.ValueString(), data.Plan.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change add-on plan, got error: %s", err))
+		return
+	}
+	data.Title = types.StringValue(addon.Title)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppAddonResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *AppAddonResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, appAddonDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	addonPath := appAddonsPath(data.AppSlug.ValueString()) + "/" + url.PathEscape(data.AddonId.ValueString())
+	err := r.client.do(ctx, http.MethodDelete, addonPath, nil, nil, nil)
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to deprovision add-on, got error: %s", err))
+		return
+	}
+}
+
+func (r *AppAddonResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "app_slug", "addon_id")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_slug"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("addon_id"), parts[1])...)
+}
+
+func appAddonsPath(appSlug string) string {
+	return "/apps/" + url.PathEscape(appSlug) + "/addons"
+}
+
+// putAppAddon provisions the add-on with the plan, or switches the plan of a
+// provisioned add-on. Repeating it is safe.
+func putAppAddon(ctx context.Context, client *BitriseClient, appSlug, addonId, planId string) (*AppAddon, error) {
+	respStruct := appAddonResponse{}
+	addonPath := appAddonsPath(appSlug) + "/" + url.PathEscape(addonId)
+	err := client.do(ctx, http.MethodPut, addonPath, nil, AppAddonParams{PlanId: planId}, &respStruct)
+	if err != nil {
+		return nil, err
+	}
+	return &respStruct.Data, nil
+}
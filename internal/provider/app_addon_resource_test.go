@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAppAddonResource(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if fake.AppAddon(app.Slug, "addons-ship") != nil {
+				return fmt.Errorf("add-on addons-ship is still provisioned")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fake.providerConfig() + testAccAppAddonResourceConfig(app.Slug, "free"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app_addon.test", "id", app.Slug+"/addons-ship"),
+					resource.TestCheckResourceAttr("bitrise_app_addon.test", "title", "Ship"),
+					testAccCheckFakeAppAddonPlan(fake, app.Slug, "addons-ship", "free"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "bitrise_app_addon.test",
+				ImportState:             true,
+				ImportStateId:           app.Slug + "/addons-ship",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Plan change in place
+			{
+				Config: fake.providerConfig() + testAccAppAddonResourceConfig(app.Slug, "pro"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app_addon.test", "plan", "pro"),
+					testAccCheckFakeAppAddonPlan(fake, app.Slug, "addons-ship", "pro"),
+				),
+			},
+			// Unknown plan
+			{
+				Config:      fake.providerConfig() + testAccAppAddonResourceConfig(app.Slug, "enterprise"),
+				ExpectError: regexp.MustCompile("has no plan enterprise"),
+			},
+		},
+	})
+}
+
+func testAccCheckFakeAppAddonPlan(fake *fakeBitrise, appSlug, addonId, planId string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		addon := fake.AppAddon(appSlug, addonId)
+		if addon == nil {
+			return fmt.Errorf("add-on %s is not provisioned", addonId)
+		}
+		if addon.Plan.Id != planId {
+			return fmt.Errorf("expected plan %q, got %q", planId, addon.Plan.Id)
+		}
+		return nil
+	}
+}
+
+func testAccAppAddonResourceConfig(appSlug, plan string) string {
+	return fmt.Sprintf(`
+resource "bitrise_app_addon" "test" {
+  app_slug = %[1]q
+  addon_id = "addons-ship"
+  plan     = %[2]q
+
+  timeouts {
+    create = "2m"
+  }
+}
+`, appSlug, plan)
+}
@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxAvatarSize is the largest avatar image the API accepts.
+const maxAvatarSize = 1 << 20
+
+// Default durations of the bitrise_app_avatar uploads, overridable with the
+// `timeouts` block.
+const (
+	appAvatarCreateTimeout = 5 * time.Minute
+	appAvatarUpdateTimeout = 5 * time.Minute
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AppAvatarResource{}
+var _ resource.ResourceWithImportState = &AppAvatarResource{}
+var _ resource.ResourceWithModifyPlan = &AppAvatarResource{}
+
+func NewAppAvatarResource() resource.Resource {
+	return &AppAvatarResource{}
+}
+
+// AppAvatarResource defines the resource implementation.
+type AppAvatarResource struct {
+	client *BitriseClient
+}
+
+// AvatarCandidate is an uploaded image which becomes the app avatar once
+// promoted.
+type AvatarCandidate struct {
+	Slug      string `json:"slug,omitempty"`
+	Filename  string `json:"filename"`
+	Filesize  int64  `json:"filesize"`
+	UploadUrl string `json:"upload_url,omitempty"`
+}
+
+type avatarCandidateListResponse struct {
+	Data []AvatarCandidate `json:"data"`
+}
+
+// AvatarPromote is the body of
+// `PATCH /apps/{app-slug}/avatar-candidates/{avatar-slug}`.
+type AvatarPromote struct {
+	IsPromoted bool `json:"is_promoted"`
+}
+
+// AppAvatarResourceModel describes the resource data model.
+type AppAvatarResourceModel struct {
+	Id         types.String   `tfsdk:"id"`
+	AppSlug    types.String   `tfsdk:"app_slug"`
+	Source     types.String   `tfsdk:"source"`
+	SourceHash types.String   `tfsdk:"source_hash"`
+	AvatarUrl  types.String   `tfsdk:"avatar_url"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *AppAvatarResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_avatar"
+}
+
+func (r *AppAvatarResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Avatar of an app. The image is uploaded again whenever its content changes. Destroying the resource keeps the current avatar, as it cannot be reset through the API. Import with the app slug.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the app",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG of the app",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: fmt.Sprintf("Path of the PNG or JPEG image, of at most %d KiB", maxAvatarSize/1024),
+			},
+			"source_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 of the uploaded image",
+			},
+			"avatar_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "URL of the avatar",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *AppAvatarResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AppAvatarResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AppAvatarResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, appAvatarCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	content, err := readAvatar(data.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source"), "Invalid Avatar", err.Error())
+		return
+	}
+	if err := r.upload(ctx, data, content); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload avatar, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(data.AppSlug.ValueString())
+
+	tflog.Trace(ctx, "uploaded an app avatar", map[string]interface{}{"bytes": len(content)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppAvatarResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AppAvatarResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app, err := getApp(ctx, r.client, data.AppSlug.ValueString())
+	if IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read App, got error: %s", err))
+		return
+	}
+	// The avatar was reset outside of Terraform, upload it again.
+	if app.AvatarUrl == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.AvatarUrl = types.StringValue(app.AvatarUrl)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppAvatarResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state *AppAvatarResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, appAvatarUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Moving the same image elsewhere needs no upload.
+	if data.SourceHash.Equal(state.SourceHash) {
+		data.AvatarUrl = state.AvatarUrl
+	} else {
+		content, err := readAvatar(data.Source.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("source"), "Invalid Avatar", err.Error())
+			return
+		}
+		if err := r.upload(ctx, data, content); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to upload avatar, got error: %s", err))
+			return
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only forgets the avatar, the API has no way to reset it.
+func (r *AppAvatarResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ModifyPlan validates the image and plans an upload when its content hash
+// differs from the uploaded one.
+func (r *AppAvatarResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to upload on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *AppAvatarResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() || plan.Source.IsUnknown() {
+		return
+	}
+
+	content, err := readAvatar(plan.Source.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source"), "Invalid Avatar", err.Error())
+		return
+	}
+	plan.SourceHash = types.StringValue(avatarHash(content))
+
+	var state *AppAvatarResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if state != nil && plan.SourceHash.Equal(state.SourceHash) {
+		plan.AvatarUrl = state.AvatarUrl
+	} else {
+		plan.AvatarUrl = types.StringUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *AppAvatarResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_slug"), req.ID)...)
+}
+
+// upload uploads content as the avatar of the app and records its hash and
+// URL in data.
+func (r *AppAvatarResource) upload(ctx context.Context, data *AppAvatarResourceModel, content []byte) error {
+	appSlug := data.AppSlug.ValueString()
+	err := uploadAvatar(ctx, r.client, appSlug, filepath.Base(data.Source.ValueString()), content)
+	if err != nil {
+		return err
+	}
+	app, err := getApp(ctx, r.client, appSlug)
+	if err != nil {
+		return err
+	}
+	data.SourceHash = types.StringValue(avatarHash(content))
+	data.AvatarUrl = types.StringValue(app.AvatarUrl)
+	return nil
+}
+
+// readAvatar reads the image at source, checking its type and size like the
+// API does.
+func readAvatar(source string) ([]byte, error) {
+	content, err := ioutil.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read avatar: %w", err)
+	}
+	if len(content) > maxAvatarSize {
+		return nil, fmt.Errorf("%s is %d bytes, avatars must be at most %d bytes", source, len(content), maxAvatarSize)
+	}
+	switch contentType := http.DetectContentType(content); contentType {
+	case "image/png", "image/jpeg":
+		return content, nil
+	default:
+		return nil, fmt.Errorf("%s is %s, avatars must be PNG or JPEG images", source, contentType)
+	}
+}
+
+func avatarHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// uploadAvatar registers an avatar candidate, uploads the image to its
+// presigned URL and promotes it to the app avatar.
+func uploadAvatar(ctx context.Context, client *BitriseClient, appSlug, filename string, content []byte) error {
+	candidatesPath := "/apps/" + url.PathEscape(appSlug) + "/avatar-candidates"
+	respStruct := avatarCandidateListResponse{}
+	candidates := []AvatarCandidate{{Filename: filename, Filesize: int64(len(content))}}
+	err := client.do(ctx, http.MethodPost, candidatesPath, nil, candidates, &respStruct)
+	if err != nil {
+		return err
+	}
+	if len(respStruct.Data) != 1 {
+		return fmt.Errorf("expected 1 avatar candidate, got %d", len(respStruct.Data))
+	}
+	candidate := respStruct.Data[0]
+
+	if err := uploadPresigned(ctx, client, candidate.UploadUrl, content); err != nil {
+		return err
+	}
+
+	promotePath := candidatesPath + "/" + url.PathEscape(candidate.Slug)
+	return client.do(ctx, http.MethodPatch, promotePath, nil, AvatarPromote{IsPromoted: true}, nil)
+}
+
+// uploadPresigned uploads content to a presigned URL, which carries its own
+// authorization. Like downloadRawLog, it uses a plain HTTP client, so the
+// request has no access token and the URL is neither logged nor retried.
+func uploadPresigned(ctx context.Context, client *BitriseClient, uploadUrl string, content []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadUrl, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	httpClient := &http.Client{Timeout: client.RequestTimeout}
+	res, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(res.Body)
+		return newAPIError(res.StatusCode, body)
+	}
+	return nil
+}
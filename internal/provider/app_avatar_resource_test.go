@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+var (
+	testPNG  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01")
+	testJPEG = []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00\x01\x01\x00\x00\x01\x00\x01\x00\x00")
+)
+
+func TestReadAvatar(t *testing.T) {
+	dir := t.TempDir()
+	cases := []struct {
+		name    string
+		content []byte
+		wantErr string
+	}{
+		{name: "avatar.png", content: testPNG},
+		{name: "avatar.jpg", content: testJPEG},
+		{name: "avatar.txt", content: []byte("not an image"), wantErr: "must be PNG or JPEG"},
+		{name: "large.png", content: append(append([]byte{}, testPNG...), make([]byte, maxAvatarSize)...), wantErr: "must be at most"},
+	}
+	for _, c := range cases {
+		source := filepath.Join(dir, c.name)
+		if err := ioutil.WriteFile(source, c.content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		content, err := readAvatar(source)
+		if c.wantErr == "" {
+			if err != nil || !bytes.Equal(content, c.content) {
+				t.Errorf("readAvatar(%s) = %d bytes, %v", c.name, len(content), err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+			t.Errorf("readAvatar(%s) error = %v, want %q", c.name, err, c.wantErr)
+		}
+	}
+
+	if _, err := readAvatar(filepath.Join(dir, "missing.png")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestAccAppAvatarResource(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	source := filepath.Join(t.TempDir(), "avatar.png")
+	updated := append(append([]byte{}, testPNG...), 0x01)
+	writeAvatar := func(content []byte) func() {
+		return func() {
+			if err := ioutil.WriteFile(source, content, 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				PreConfig: writeAvatar(testPNG),
+				Config:    fake.providerConfig() + testAccAppAvatarResourceConfig(app.Slug, source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app_avatar.test", "id", app.Slug),
+					resource.TestCheckResourceAttr("bitrise_app_avatar.test", "source_hash", avatarHash(testPNG)),
+					resource.TestMatchResourceAttr("bitrise_app_avatar.test", "avatar_url", regexp.MustCompile(`/avatars/\w+\.png$`)),
+					func(*terraform.State) error {
+						if !bytes.Equal(fake.Avatar(app.Slug), testPNG) {
+							return fmt.Errorf("expected the image to be uploaded")
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "bitrise_app_avatar.test",
+				ImportState:             true,
+				ImportStateId:           app.Slug,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source", "source_hash"},
+			},
+			// Changing the image content uploads it again
+			{
+				PreConfig: writeAvatar(updated),
+				Config:    fake.providerConfig() + testAccAppAvatarResourceConfig(app.Slug, source),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app_avatar.test", "source_hash", avatarHash(updated)),
+					resource.TestCheckResourceAttrWith("bitrise_app_avatar.test", "avatar_url", func(value string) error {
+						if value != fake.App(app.Slug).AvatarUrl {
+							return fmt.Errorf("expected avatar_url %q, got %q", fake.App(app.Slug).AvatarUrl, value)
+						}
+						return nil
+					}),
+					func(*terraform.State) error {
+						if !bytes.Equal(fake.Avatar(app.Slug), updated) {
+							return fmt.Errorf("expected the updated image to be uploaded")
+						}
+						return nil
+					},
+				),
+			},
+			// Files which are not images are rejected at plan time
+			{
+				PreConfig:   writeAvatar([]byte("not an image")),
+				Config:      fake.providerConfig() + testAccAppAvatarResourceConfig(app.Slug, source),
+				ExpectError: regexp.MustCompile("Invalid Avatar"),
+			},
+		},
+	})
+}
+
+func testAccAppAvatarResourceConfig(appSlug, source string) string {
+	return fmt.Sprintf(`
+resource "bitrise_app_avatar" "test" {
+  app_slug = %[1]q
+  source   = %[2]q
+}
+`, appSlug, source)
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AppBuildTriggerTokenResource{}
+var _ resource.ResourceWithImportState = &AppBuildTriggerTokenResource{}
+
+func NewAppBuildTriggerTokenResource() resource.Resource {
+	return &AppBuildTriggerTokenResource{}
+}
+
+// AppBuildTriggerTokenResource defines the resource implementation.
+type AppBuildTriggerTokenResource struct {
+	client *BitriseClient
+}
+
+// BuildTriggerToken is the response of `/apps/{app-slug}/build-trigger-token`.
+type BuildTriggerToken struct {
+	Token string `json:"build_trigger_token"`
+}
+
+// AppBuildTriggerTokenResourceModel describes the resource data model.
+type AppBuildTriggerTokenResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	AppSlug          types.String `tfsdk:"app_slug"`
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"`
+	Token            types.String `tfsdk:"token"`
+}
+
+func (r *AppBuildTriggerTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_build_trigger_token"
+}
+
+func (r *AppBuildTriggerTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Build trigger token of an app. Creating the resource regenerates the token, which invalidates the previous one, and destroying it keeps the current token. Import with the app slug to adopt the current token without regenerating it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the app",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG of the app",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values which regenerate the token when they change, e.g. a `time_rotating` timestamp",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Build trigger token, e.g. for a GitHub Actions secret",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AppBuildTriggerTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AppBuildTriggerTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AppBuildTriggerTokenResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := BuildTriggerToken{}
+	err := r.client.do(ctx, http.MethodPatch, buildTriggerTokenPath(data.AppSlug.ValueString()), nil, nil, &respStruct)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to regenerate build trigger token, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.AppSlug.ValueString())
+	data.Token = types.StringValue(respStruct.Token)
+
+	tflog.Trace(ctx, "regenerated a build trigger token")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppBuildTriggerTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AppBuildTriggerTokenResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := BuildTriggerToken{}
+	err := r.client.do(ctx, http.MethodGet, buildTriggerTokenPath(data.AppSlug.ValueString()), nil, nil, &respStruct)
+	if IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read build trigger token, got error: %s", err))
+		return
+	}
+
+	// A token regenerated outside of Terraform is adopted as is.
+	data.Token = types.StringValue(respStruct.Token)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppBuildTriggerTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *AppBuildTriggerTokenResourceModel
+
+	// Every configurable attribute requires replacement, so there is nothing
+	// to update.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only forgets the token: an app always has one, and regenerating it
+// would break every integration using it.
+func (r *AppBuildTriggerTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *AppBuildTriggerTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_slug"), req.ID)...)
+}
+
+func buildTriggerTokenPath(appSlug string) string {
+	return "/apps/" + url.PathEscape(appSlug) + "/build-trigger-token"
+}
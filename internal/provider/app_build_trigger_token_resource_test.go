@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAppBuildTriggerTokenResource(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	var rotated string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create regenerates the token
+			{
+				Config: fake.providerConfig() + testAccAppBuildTriggerTokenResourceConfig(app.Slug, "2023-04-01"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app_build_trigger_token.test", "id", app.Slug),
+					func(s *terraform.State) error {
+						rotated = s.RootModule().Resources["bitrise_app_build_trigger_token.test"].Primary.Attributes["token"]
+						if rotated == "" || rotated != fake.TriggerToken(app.Slug) {
+							return fmt.Errorf("expected token %q, got %q", fake.TriggerToken(app.Slug), rotated)
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "bitrise_app_build_trigger_token.test",
+				ImportState:             true,
+				ImportStateId:           app.Slug,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotation_triggers"},
+			},
+			// Changing a rotation trigger regenerates the token again
+			{
+				Config: fake.providerConfig() + testAccAppBuildTriggerTokenResourceConfig(app.Slug, "2023-07-01"),
+				Check: func(s *terraform.State) error {
+					token := s.RootModule().Resources["bitrise_app_build_trigger_token.test"].Primary.Attributes["token"]
+					if token == rotated {
+						return fmt.Errorf("expected the token to be regenerated")
+					}
+					if token != fake.TriggerToken(app.Slug) {
+						return fmt.Errorf("expected token %q, got %q", fake.TriggerToken(app.Slug), token)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccAppBuildTriggerTokenResourceConfig(appSlug, rotatedOn string) string {
+	return fmt.Sprintf(`
+resource "bitrise_app_build_trigger_token" "test" {
+  app_slug = %[1]q
+  rotation_triggers = {
+    rotated_on = %[2]q
+  }
+}
+`, appSlug, rotatedOn)
+}
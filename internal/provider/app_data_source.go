@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AppDataSource{}
+
+func NewAppDataSource() datasource.DataSource {
+	return &AppDataSource{}
+}
+
+// AppDataSource defines the data source implementation.
+type AppDataSource struct {
+	client *BitriseClient
+}
+
+// App is an app as returned by the Bitrise API.
+type App struct {
+	Slug        string   `json:"slug"`
+	Title       string   `json:"title"`
+	ProjectType string   `json:"project_type"`
+	Provider    string   `json:"provider"`
+	RepoOwner   string   `json:"repo_owner"`
+	RepoUrl     string   `json:"repo_url"`
+	RepoSlug    string   `json:"repo_slug"`
+	IsDisabled  bool     `json:"is_disabled"`
+	Status      int64    `json:"status"`
+	IsPublic    bool     `json:"is_public"`
+	Owner       AppOwner `json:"owner"`
+	AvatarUrl   string   `json:"avatar_url"`
+}
+
+// AppOwner is the user or organization an app belongs to.
+type AppOwner struct {
+	AccountType string `json:"account_type"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+}
+
+type appResponse struct {
+	Data App `json:"data"`
+}
+
+type appListResponse struct {
+	Data   []App  `json:"data"`
+	Paging Paging `json:"paging"`
+}
+
+// AppDataSourceModel describes the data source data model. Field names follow
+// AppResourceModel so the two can be used interchangeably in configurations.
+type AppDataSourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	Slug             types.String `tfsdk:"slug"`
+	Title            types.String `tfsdk:"title"`
+	OrganizationSlug types.String `tfsdk:"organization_slug"`
+	RepoProvider     types.String `tfsdk:"repo_provider"`
+	IsPublic         types.Bool   `tfsdk:"is_public"`
+	RepoUrl          types.String `tfsdk:"repo_url"`
+	GitRepoSlug      types.String `tfsdk:"git_repo_slug"`
+	GitOwner         types.String `tfsdk:"git_owner"`
+	ProjectType      types.String `tfsdk:"project_type"`
+	Status           types.Int64  `tfsdk:"status"`
+	AvatarUrl        types.String `tfsdk:"avatar_url"`
+}
+
+func (d *AppDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app"
+}
+
+func (d *AppDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up an existing app either by `slug`, or by `title` within an organization.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the app",
+			},
+			"slug": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "SLUG of the app. Conflicts with `title`.",
+			},
+			"title": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Title of the app. Requires `organization_slug` and must match exactly one app.",
+			},
+			"organization_slug": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "SLUG for the organization owning the app",
+			},
+			"repo_provider": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Repo provider",
+			},
+			"is_public": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Is the app public or private",
+			},
+			"repo_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "URL for the git repository",
+			},
+			"git_repo_slug": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the git repository",
+			},
+			"git_owner": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Owner of the git repository",
+			},
+			"project_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Project type of the app",
+			},
+			"status": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Status of the app",
+			},
+			"avatar_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "URL of the app avatar",
+			},
+		},
+	}
+}
+
+func (d *AppDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AppDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var app *App
+	switch {
+	case !data.Slug.IsNull() && !data.Title.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("slug"), "Conflicting Attributes", "Only one of `slug` and `title` can be set.")
+		return
+	case !data.Slug.IsNull():
+		found, err := getApp(ctx, d.client, data.Slug.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read app %q, got error: %s", data.Slug.ValueString(), err))
+			return
+		}
+		app = found
+	case !data.Title.IsNull():
+		if data.OrganizationSlug.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("organization_slug"), "Missing Attribute", "`organization_slug` is required when looking up an app by `title`.")
+			return
+		}
+		found, err := findAppByTitle(ctx, d.client, data.OrganizationSlug.ValueString(), data.Title.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find app %q, got error: %s", data.Title.ValueString(), err))
+			return
+		}
+		app = found
+	default:
+		resp.Diagnostics.AddError("Missing Attribute", "One of `slug` or `title` must be set.")
+		return
+	}
+
+	data.Id = types.StringValue(app.Slug)
+	data.Slug = types.StringValue(app.Slug)
+	data.Title = types.StringValue(app.Title)
+	data.OrganizationSlug = types.StringValue(app.Owner.Slug)
+	data.RepoProvider = types.StringValue(app.Provider)
+	data.IsPublic = types.BoolValue(app.IsPublic)
+	data.RepoUrl = types.StringValue(app.RepoUrl)
+	data.GitRepoSlug = types.StringValue(app.RepoSlug)
+	data.GitOwner = types.StringValue(app.RepoOwner)
+	data.ProjectType = types.StringValue(app.ProjectType)
+	data.Status = types.Int64Value(app.Status)
+	data.AvatarUrl = types.StringValue(app.AvatarUrl)
+
+	tflog.Trace(ctx, "read an app data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func getApp(ctx context.Context, client *BitriseClient, slug string) (*App, error) {
+	respStruct := appResponse{}
+	err := client.do(ctx, http.MethodGet, "/apps/"+url.PathEscape(slug), nil, nil, &respStruct)
+	if err != nil {
+		return nil, err
+	}
+	return &respStruct.Data, nil
+}
+
+// listApps follows the `next` cursor of the list endpoint at path until every
+// app matching query has been fetched.
+func listApps(ctx context.Context, client *BitriseClient, path string, query url.Values) ([]App, error) {
+	var apps []App
+	params := url.Values{}
+	for k, v := range query {
+		params[k] = v
+	}
+	for {
+		respStruct := appListResponse{}
+		err := client.do(ctx, http.MethodGet, path, params, nil, &respStruct)
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, respStruct.Data...)
+		if respStruct.Paging.Next == "" {
+			return apps, nil
+		}
+		params.Set("next", respStruct.Paging.Next)
+	}
+}
+
+func organizationAppsPath(orgSlug string) string {
+	return "/organizations/" + url.PathEscape(orgSlug) + "/apps"
+}
+
+// findAppByTitle returns the single app of the organization titled exactly
+// title. The API filter matches substrings, so the exact match is done here.
+func findAppByTitle(ctx context.Context, client *BitriseClient, orgSlug, title string) (*App, error) {
+	apps, err := listApps(ctx, client, organizationAppsPath(orgSlug), url.Values{"title": []string{title}})
+	if err != nil {
+		return nil, err
+	}
+	var matches []App
+	for _, app := range apps {
+		if app.Title == title {
+			matches = append(matches, app)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no app titled %q in organization %q", title, orgSlug)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d apps titled %q in organization %q, use `slug` instead", len(matches), title, orgSlug)
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestFindAppByTitle(t *testing.T) {
+	pages := map[string]appListResponse{
+		"": {
+			Data:   []App{{Slug: "a1", Title: "mobile"}, {Slug: "a2", Title: "mobile-legacy"}},
+			Paging: Paging{Next: "a2"},
+		},
+		"a2": {
+			Data: []App{{Slug: "a3", Title: "mobile-next"}, {Slug: "a4", Title: "web"}},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/organizations/org/apps" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(pages[r.URL.Query().Get("next")])
+	}))
+	defer server.Close()
+
+	client := &BitriseClient{HTTPClient: server.Client(), Endpoint: server.URL, Token: "token"}
+
+	app, err := findAppByTitle(context.Background(), client, "org", "mobile")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if app.Slug != "a1" {
+		t.Errorf("expected slug a1, got %s", app.Slug)
+	}
+
+	app, err = findAppByTitle(context.Background(), client, "org", "web")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if app.Slug != "a4" {
+		t.Errorf("expected slug a4 from the second page, got %s", app.Slug)
+	}
+
+	if _, err := findAppByTitle(context.Background(), client, "org", "missing"); err == nil {
+		t.Error("expected an error for an unknown title")
+	}
+
+	pages[""] = appListResponse{
+		Data:   []App{{Slug: "a1", Title: "mobile"}, {Slug: "a5", Title: "mobile"}},
+		Paging: Paging{Next: "a2"},
+	}
+	if _, err := findAppByTitle(context.Background(), client, "org", "mobile"); err == nil {
+		t.Error("expected an error for a duplicated title")
+	}
+}
+
+func TestAccAppDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{
+		Title:       "mobile",
+		ProjectType: "ios",
+		Provider:    "github",
+		RepoOwner:   "pgdevelopers",
+		RepoSlug:    "mobile",
+		RepoUrl:     "git@github.com:pgdevelopers/mobile.git",
+		Status:      1,
+		Owner:       AppOwner{AccountType: "organization", Slug: fakeOrgSlug},
+	})
+	fake.AddApp(App{Title: "web", Owner: AppOwner{Slug: fakeOrgSlug}})
+	fake.AddApp(App{Title: "mobile-legacy", Owner: AppOwner{Slug: fakeOrgSlug}})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read by slug
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_app" "test" {
+  slug = %q
+}
+`, app.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_app.test", "id", app.Slug),
+					resource.TestCheckResourceAttr("data.bitrise_app.test", "title", "mobile"),
+					resource.TestCheckResourceAttr("data.bitrise_app.test", "organization_slug", fakeOrgSlug),
+					resource.TestCheckResourceAttr("data.bitrise_app.test", "project_type", "ios"),
+					resource.TestCheckResourceAttr("data.bitrise_app.test", "git_owner", "pgdevelopers"),
+				),
+			},
+			// Read by title, across pages
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_app" "test" {
+  organization_slug = %q
+  title             = "mobile"
+}
+`, fakeOrgSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_app.test", "slug", app.Slug),
+				),
+			},
+		},
+	})
+}
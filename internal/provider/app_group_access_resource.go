@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AppGroupAccessResource{}
+var _ resource.ResourceWithImportState = &AppGroupAccessResource{}
+
+// appRoleLocks serialize the read-modify-write of an app role's group list,
+// as Terraform may grant several groups the same role in parallel.
+var (
+	appRoleLocksMutex sync.Mutex
+	appRoleLocks      = map[string]*sync.Mutex{}
+)
+
+func NewAppGroupAccessResource() resource.Resource {
+	return &AppGroupAccessResource{}
+}
+
+// AppGroupAccessResource defines the resource implementation.
+type AppGroupAccessResource struct {
+	client *BitriseClient
+}
+
+// AppRole lists the groups holding a role on an app.
+type AppRole struct {
+	Groups []string `json:"groups"`
+}
+
+// AppGroupAccessResourceModel describes the resource data model.
+type AppGroupAccessResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	AppSlug   types.String `tfsdk:"app_slug"`
+	GroupSlug types.String `tfsdk:"group_slug"`
+	Role      types.String `tfsdk:"role"`
+}
+
+func (r *AppGroupAccessResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_group_access"
+}
+
+func (r *AppGroupAccessResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Grants a group a role on an app. Import with `<app_slug>/<role>/<group_slug>`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "`<app_slug>/<role>/<group_slug>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG of the app",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG of the group",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Role granted on the app, one of `admin`, `manager`, `member` or `platform_engineer`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("admin", "manager", "member", "platform_engineer"),
+				},
+			},
+		},
+	}
+}
+
+func (r *AppGroupAccessResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AppGroupAccessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *AppGroupAccessResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateAppRoleGroups(ctx, r.client, data.AppSlug.ValueString(), data.Role.ValueString(), func(groups []string) []string {
+		for _, group := range groups {
+			if group == data.GroupSlug.ValueString() {
+				return groups
+			}
+		}
+		return append(groups, data.GroupSlug.ValueString())
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant app access, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.AppSlug.ValueString() + "/" + data.Role.ValueString() + "/" + data.GroupSlug.ValueString())
+
+	tflog.Trace(ctx, "created an app group access")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppGroupAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *AppGroupAccessResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := getAppRole(ctx, r.client, data.AppSlug.ValueString(), data.Role.ValueString())
+	if IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read app role, got error: %s", err))
+		return
+	}
+
+	found := false
+	for _, group := range role.Groups {
+		if group == data.GroupSlug.ValueString() {
+			found = true
+			break
+		}
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppGroupAccessResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *AppGroupAccessResourceModel
+
+	// Every attribute requires replacement, so there is nothing to update.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppGroupAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *AppGroupAccessResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateAppRoleGroups(ctx, r.client, data.AppSlug.ValueString(), data.Role.ValueString(), func(groups []string) []string {
+		kept := []string{}
+		for _, group := range groups {
+			if group != data.GroupSlug.ValueString() {
+				kept = append(kept, group)
+			}
+		}
+		return kept
+	})
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke app access, got error: %s", err))
+		return
+	}
+}
+
+func (r *AppGroupAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "app_slug", "role", "group_slug")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_slug"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_slug"), parts[2])...)
+}
+
+func appRolePath(appSlug, role string) string {
+	return "/apps/" + url.PathEscape(appSlug) + "/roles/" + url.PathEscape(role)
+}
+
+func getAppRole(ctx context.Context, client *BitriseClient, appSlug, role string) (*AppRole, error) {
+	respStruct := AppRole{}
+	err := client.do(ctx, http.MethodGet, appRolePath(appSlug, role), nil, nil, &respStruct)
+	if err != nil {
+		return nil, err
+	}
+	return &respStruct, nil
+}
+
+// updateAppRoleGroups replaces the groups holding role on the app with the
+// result of change applied to the current groups.
+func updateAppRoleGroups(ctx context.Context, client *BitriseClient, appSlug, role string, change func([]string) []string) error {
+	lock := appRoleLock(appSlug + "/" + role)
+	lock.Lock()
+	defer lock.Unlock()
+
+	current, err := getAppRole(ctx, client, appSlug, role)
+	if err != nil {
+		return err
+	}
+	updated := AppRole{Groups: change(current.Groups)}
+	return client.do(ctx, http.MethodPut, appRolePath(appSlug, role), nil, updated, nil)
+}
+
+func appRoleLock(key string) *sync.Mutex {
+	appRoleLocksMutex.Lock()
+	defer appRoleLocksMutex.Unlock()
+	lock, ok := appRoleLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		appRoleLocks[key] = lock
+	}
+	return lock
+}
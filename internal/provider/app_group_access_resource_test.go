@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestUpdateAppRoleGroupsConcurrent(t *testing.T) {
+	var mutex sync.Mutex
+	role := AppRole{Groups: []string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&role)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(role)
+	}))
+	defer server.Close()
+
+	client := &BitriseClient{HTTPClient: server.Client(), Endpoint: server.URL, Token: "token"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(group string) {
+			defer wg.Done()
+			err := updateAppRoleGroups(context.Background(), client, "app", "member", func(groups []string) []string {
+				return append(groups, group)
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}(fmt.Sprintf("group-%d", i))
+	}
+	wg.Wait()
+
+	if len(role.Groups) != 10 {
+		t.Errorf("expected every concurrent grant to be kept, got %v", role.Groups)
+	}
+}
+
+func TestAccAppGroupAccessResource(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fake.providerConfig() + testAccAppGroupAccessResourceConfig(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app_group_access.first", "role", "member"),
+					resource.TestCheckResourceAttr("bitrise_app_group_access.second", "role", "member"),
+					testAccCheckFakeAppRoleGroups(fake, "bitrise_app.test", "member", 2),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bitrise_app_group_access.first",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckFakeAppRoleGroups verifies that concurrent grants on the same
+// role did not overwrite each other.
+func testAccCheckFakeAppRoleGroups(fake *fakeBitrise, name, role string, want int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		if groups := fake.AppRoleGroups(rs.Primary.ID, role); len(groups) != want {
+			return fmt.Errorf("expected %d groups with role %s, got %v", want, role, groups)
+		}
+		return nil
+	}
+}
+
+func testAccAppGroupAccessResourceConfig() string {
+	return testAccAppResourceConfig("mobile", fakeOrgSlug) + fmt.Sprintf(`
+resource "bitrise_organization_group" "first" {
+  organization_slug = %[1]q
+  name              = "first"
+}
+
+resource "bitrise_organization_group" "second" {
+  organization_slug = %[1]q
+  name              = "second"
+}
+
+resource "bitrise_app_group_access" "first" {
+  app_slug   = bitrise_app.test.id
+  group_slug = bitrise_organization_group.first.id
+  role       = "member"
+}
+
+resource "bitrise_app_group_access" "second" {
+  app_slug   = bitrise_app.test.id
+  group_slug = bitrise_organization_group.second.id
+  role       = "member"
+}
+`, fakeOrgSlug)
+}
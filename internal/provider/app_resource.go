@@ -1,26 +1,48 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// Default durations of the bitrise_app operations, overridable with the
+// `timeouts` block.
+const (
+	appCreateTimeout = 10 * time.Minute
+	appReadTimeout   = 5 * time.Minute
+	appUpdateTimeout = 10 * time.Minute
+	appDeleteTimeout = 10 * time.Minute
+)
+
+// abortOnDestroyReason is the abort reason of the builds aborted before their
+// app is deleted.
+const abortOnDestroyReason = "The app is being deleted by Terraform."
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &AppResource{}
 var _ resource.ResourceWithImportState = &AppResource{}
+var _ resource.ResourceWithModifyPlan = &AppResource{}
+var _ resource.ResourceWithUpgradeState = &AppResource{}
 
 func NewAppResource() resource.Resource {
 	return &AppResource{}
@@ -28,7 +50,7 @@ func NewAppResource() resource.Resource {
 
 // AppResource defines the resource implementation.
 type AppResource struct {
-	client *http.Client
+	client *BitriseClient
 }
 
 // Response from calling register endpoint
@@ -56,6 +78,29 @@ type Register struct {
 	Title            string `json:"title"`
 }
 
+// AppUpdate is the body of `PATCH /apps/{app-slug}`.
+type AppUpdate struct {
+	Title       string `json:"title,omitempty"`
+	ProjectType string `json:"project_type,omitempty"`
+	RepoUrl     string `json:"repo_url,omitempty"`
+	Provider    string `json:"provider,omitempty"`
+	GitOwner    string `json:"git_owner,omitempty"`
+	GitRepoSlug string `json:"git_repo_slug,omitempty"`
+	IsPublic    *bool  `json:"is_public,omitempty"`
+}
+
+// AppTransfer is the body of `POST /apps/{app-slug}/transfer`.
+type AppTransfer struct {
+	OrganizationSlug string `json:"organization_slug"`
+}
+
+// BuildAbort is the body of `POST /apps/{app-slug}/builds/{build-slug}/abort`.
+type BuildAbort struct {
+	AbortReason       string `json:"abort_reason"`
+	AbortWithSuccess  bool   `json:"abort_with_success"`
+	SkipNotifications bool   `json:"skip_notifications"`
+}
+
 type Finish struct {
 	ProjectType      string `json:"project_type"`
 	StackID          string `json:"stack_id"`
@@ -66,19 +111,22 @@ type Finish struct {
 
 // AppResourceModel describes the resource data model.
 type AppResourceModel struct {
-	Token            types.String `tfsdk:"token"`
-	RepoProvider     types.String `tfsdk:"repo_provider"`
-	IsPublic         types.Bool   `tfsdk:"is_public"`
-	OrganizationSlug types.String `tfsdk:"organization_slug"`
-	RepoUrl          types.String `tfsdk:"repo_url"`
-	Type             types.String `tfsdk:"type"`
-	GitRepoSlug      types.String `tfsdk:"git_repo_slug"`
-	GitOwner         types.String `tfsdk:"git_owner"`
-	Title            types.String `tfsdk:"title"`
-	ProjectType      types.String `tfsdk:"project_type"`
-	StackID          types.String `tfsdk:"stack_id"`
-	Config           types.String `tfsdk:"config"`
-	Mode             types.String `tfsdk:"mode"`
+	Id               types.String   `tfsdk:"id"`
+	Token            types.String   `tfsdk:"token"`
+	RepoProvider     types.String   `tfsdk:"repo_provider"`
+	IsPublic         types.Bool     `tfsdk:"is_public"`
+	OrganizationSlug types.String   `tfsdk:"organization_slug"`
+	RepoUrl          types.String   `tfsdk:"repo_url"`
+	Type             types.String   `tfsdk:"type"`
+	GitRepoSlug      types.String   `tfsdk:"git_repo_slug"`
+	GitOwner         types.String   `tfsdk:"git_owner"`
+	Title            types.String   `tfsdk:"title"`
+	ProjectType      types.String   `tfsdk:"project_type"`
+	StackID          types.String   `tfsdk:"stack_id"`
+	Config           types.String   `tfsdk:"config"`
+	Mode             types.String   `tfsdk:"mode"`
+	AbortOnDestroy   types.Bool     `tfsdk:"abort_running_builds_on_destroy"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *AppResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -90,16 +138,30 @@ func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "App resource",
 
+		// Version 1 requires the app slug in `id`.
+		Version: 1,
+
 		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the app",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"token": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Bitrise access token",
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Bitrise access token, for providers without a `token`. A token set here is stored in the state. It conflicts with the provider `token`, which is never stored in the state.",
+				DeprecationMessage:  "Configure the token on the provider instead, so it is not stored in the state of every app.",
 			},
 			"repo_provider": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Repo provider",
 				Computed:            true,
-				Default:             stringdefault.StaticString("github"),
+				MarkdownDescription: "Provider of the git repository, one of `" + strings.Join(repoProviders, "`, `") + "`. Defaults to the provider of the `repo_url` host for github.com, gitlab.com and bitbucket.org, and must be set for other hosts.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoProviders...),
+				},
 			},
 			"is_public": schema.BoolAttribute{
 				MarkdownDescription: "Is the app public or private",
@@ -110,28 +172,31 @@ func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 			"organization_slug": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "SLUG for the organization",
+				MarkdownDescription: "SLUG for the organization. Changing it transfers the app to the new organization, keeping its build history.",
 				Default:             stringdefault.StaticString("cf38e3d194d03fa2"),
 			},
 			"repo_url": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "URL for the git repository",
+				MarkdownDescription: "HTTPS or SSH URL of the git repository, such as `https://github.com/<owner>/<repository>.git` or `git@github.com:<owner>/<repository>.git`. Bitbucket Server HTTPS URLs have the form `https://<host>/scm/<project>/<repository>.git`.",
 			},
 			"type": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
 				MarkdownDescription: "Type of the repository",
 				Default:             stringdefault.StaticString("git"),
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfManaged(),
+				},
 			},
 			"git_repo_slug": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Name of the git repository",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the git repository. Defaults to the name in `repo_url`, which it must match unless `repo_provider` is `custom`.",
 			},
 			"git_owner": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Github org name",
-				Default:             stringdefault.StaticString("pgdevelopers"),
+				MarkdownDescription: "Owner of the git repository: the user or organization, the GitLab group including its subgroups, or the Bitbucket Server project key. Defaults to the owner in `repo_url`, which it must match unless `repo_provider` is `custom`. Required for custom repositories whose `repo_url` has no owner.",
 			},
 			"title": schema.StringAttribute{
 				Optional:            true,
@@ -144,18 +209,42 @@ func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 			"stack_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Not sure?",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfManaged(),
+				},
 			},
 			"config": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "OS configuration?",
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfManaged(),
+				},
 			},
 			"mode": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
 				MarkdownDescription: "Must be manual",
 				Default:             stringdefault.StaticString("manual"),
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceIfManaged(),
+				},
+			},
+			"abort_running_builds_on_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Abort the running builds of the app before deleting it, including when it is replaced, so they stop consuming credits. Defaults to `false`.",
+				Default:             booldefault.StaticBool(false),
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -165,12 +254,12 @@ func (r *AppResource) Configure(ctx context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	client, ok := req.ProviderData.(*BitriseClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -179,6 +268,17 @@ func (r *AppResource) Configure(ctx context.Context, req resource.ConfigureReque
 	r.client = client
 }
 
+// appClient returns the client managing the app: the provider one when the
+// provider has a token, which is then never stored in the state, or one with
+// the resource token otherwise.
+func (r *AppResource) appClient(data *AppResourceModel) *BitriseClient {
+	if r.client.Token != "" {
+		data.Token = types.StringNull()
+		return r.client
+	}
+	return r.client.withToken(data.Token.ValueString())
+}
+
 func (r *AppResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data *AppResourceModel
 
@@ -189,12 +289,37 @@ func (r *AppResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, appCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	//***************************** API CALL *******************************
-	slug, err := register(data)
+	client := r.appClient(data)
+	if client.Token == "" {
+		resp.Diagnostics.AddError(
+			"Missing Bitrise Access Token",
+			"Set the `token` provider attribute or the `BITRISE_TOKEN` environment variable to a Bitrise personal access token.",
+		)
+		return
+	}
+	slug, err := register(ctx, client, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to register App, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(slug)
+	_, err = finish(ctx, client, data, slug)
 	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to finish registering App %s, got error: %s", slug, err))
+		// Keep the registered app in the state, Terraform taints it so the
+		// next apply replaces it instead of registering a second app.
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 		return
 	}
-	_, err = finish(data, slug)
 	//*****************************
 
 	tflog.Trace(ctx, "created a resource")
@@ -213,35 +338,123 @@ func (r *AppResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read App, got error: %s", err))
-	//     return
-	// }
+	readTimeout, diags := data.Timeouts.Read(ctx, appReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	client := r.appClient(data)
+	// States upgraded from version 0 may lack the app slug.
+	if data.Id.ValueString() == "" {
+		app, err := findAppByRepo(ctx, client, data.OrganizationSlug.ValueString(), data.GitRepoSlug.ValueString(), data.Title.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Find App",
+				fmt.Sprintf("Unable to find the app of git repository %q in organization %q, got error: %s. "+
+					"Remove it with `terraform state rm` and import it with its slug instead.",
+					data.GitRepoSlug.ValueString(), data.OrganizationSlug.ValueString(), err),
+			)
+			return
+		}
+		data.Id = types.StringValue(app.Slug)
+	}
+
+	app, err := getApp(ctx, client, data.Id.ValueString())
+	if IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read App, got error: %s", err))
+		return
+	}
+
+	data.OrganizationSlug = types.StringValue(app.Owner.Slug)
+	data.RepoProvider = types.StringValue(app.Provider)
+	data.IsPublic = types.BoolValue(app.IsPublic)
+	data.RepoUrl = types.StringValue(app.RepoUrl)
+	data.GitRepoSlug = types.StringValue(app.RepoSlug)
+	data.GitOwner = types.StringValue(app.RepoOwner)
+	data.ProjectType = types.StringValue(app.ProjectType)
+	// Imported apps have no option yet, take the default.
+	if data.AbortOnDestroy.IsNull() {
+		data.AbortOnDestroy = types.BoolValue(false)
+	}
+	// title is not computed, only refresh it when it is managed.
+	if !data.Title.IsNull() {
+		data.Title = types.StringValue(app.Title)
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AppResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data *AppResourceModel
+	var data, state *AppResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, appUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	client := r.appClient(data)
+	slug := state.Id.ValueString()
+
+	if !data.OrganizationSlug.Equal(state.OrganizationSlug) {
+		err := transferApp(ctx, client, slug, data.OrganizationSlug.ValueString())
+		if apiErr, ok := err.(*APIError); ok && (apiErr.StatusCode == http.StatusForbidden || apiErr.StatusCode == http.StatusUnauthorized) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("organization_slug"),
+				"Insufficient Rights in Target Organization",
+				fmt.Sprintf("The token is not allowed to transfer App %s to organization %q: %s. "+
+					"The token owner must be an owner of both organizations.", slug, data.OrganizationSlug.ValueString(), apiErr),
+			)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to transfer App %s, got error: %s", slug, err))
+			return
+		}
+		tflog.Info(ctx, "transferred app", map[string]interface{}{"slug": slug, "organization_slug": data.OrganizationSlug.ValueString()})
+		// Record the transfer right away, so a failing update below does not
+		// transfer the app again on the next apply.
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_slug"), data.OrganizationSlug)...)
+	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update App, got error: %s", err))
-	//     return
-	// }
+	if !data.Title.Equal(state.Title) || !data.ProjectType.Equal(state.ProjectType) ||
+		!data.RepoUrl.Equal(state.RepoUrl) || !data.RepoProvider.Equal(state.RepoProvider) ||
+		!data.GitOwner.Equal(state.GitOwner) || !data.GitRepoSlug.Equal(state.GitRepoSlug) ||
+		!data.IsPublic.Equal(state.IsPublic) {
+		isPublic := data.IsPublic.ValueBool()
+		update := AppUpdate{
+			Title:       data.Title.ValueString(),
+			ProjectType: data.ProjectType.ValueString(),
+			RepoUrl:     data.RepoUrl.ValueString(),
+			Provider:    data.RepoProvider.ValueString(),
+			GitOwner:    data.GitOwner.ValueString(),
+			GitRepoSlug: data.GitRepoSlug.ValueString(),
+			IsPublic:    &isPublic,
+		}
+		err := client.do(withIdempotentRetries(ctx), http.MethodPatch, "/apps/"+url.PathEscape(slug), nil, update, nil)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update App %s, got error: %s", slug, err))
+			return
+		}
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -257,20 +470,312 @@ func (r *AppResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete App, got error: %s", err))
-	//     return
-	// }
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, appDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	client := r.appClient(data)
+
+	if data.AbortOnDestroy.ValueBool() {
+		aborted, err := abortRunningBuilds(ctx, client, data.Id.ValueString(), abortOnDestroyReason)
+		// Report the builds aborted before a failure too.
+		if len(aborted) > 0 {
+			numbers := make([]string, len(aborted))
+			for i, build := range aborted {
+				numbers[i] = fmt.Sprintf("#%d (%s)", build.BuildNumber, build.Slug)
+			}
+			resp.Diagnostics.AddWarning(
+				"Running Builds Aborted",
+				fmt.Sprintf("Aborted %d running builds of app %s before deleting it: %s.",
+					len(aborted), data.Id.ValueString(), strings.Join(numbers, ", ")),
+			)
+		}
+		if err != nil && !IsNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to abort running builds of App, got error: %s", err))
+			return
+		}
+	}
+
+	err := client.do(ctx, http.MethodDelete, "/apps/"+url.PathEscape(data.Id.ValueString()), nil, nil, nil)
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete App, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan fills the repository attributes from `repo_url` and checks they
+// are consistent with it. It also warns when a plan transfers the app to
+// another organization and fails early when the token has no access to the
+// target organization.
+func (r *AppResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config, plan, state *AppResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client != nil && r.client.Token != "" && !config.Token.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Conflicting Bitrise Access Tokens",
+			"The provider already has a token, which is used instead and kept out of the state. Remove `token` from the app.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(planRepository(config, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+
+	// Nothing to transfer on create.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.OrganizationSlug.IsUnknown() || plan.OrganizationSlug.Equal(state.OrganizationSlug) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("organization_slug"),
+		"App Ownership Transfer",
+		fmt.Sprintf("App %s will be transferred from organization %q to organization %q. "+
+			"The app keeps its slug and build history, but billing, concurrency and team access now follow the new organization.",
+			state.Id.ValueString(), state.OrganizationSlug.ValueString(), plan.OrganizationSlug.ValueString()),
+	)
+
+	if r.client == nil {
+		return
+	}
+	_, err := getOrganization(ctx, r.appClient(plan), plan.OrganizationSlug.ValueString())
+	if apiErr, ok := err.(*APIError); ok && (apiErr.StatusCode == http.StatusForbidden || apiErr.StatusCode == http.StatusNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("organization_slug"),
+			"Insufficient Rights in Target Organization",
+			fmt.Sprintf("The token has no access to organization %q, so App %s cannot be transferred there: %s",
+				plan.OrganizationSlug.ValueString(), state.Id.ValueString(), apiErr),
+		)
+	}
+}
+
+// planRepository fills the provider, owner and name of the repository left
+// unset in config from the `repo_url` of plan, and checks that the ones set
+// match it. Custom repositories have no known URL format, so their owner and
+// name are taken as set, and only derived when unset.
+func planRepository(config, plan *AppResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.RepoUrl.IsUnknown() || config.RepoProvider.IsUnknown() {
+		return diags
+	}
+
+	repoUrl := plan.RepoUrl.ValueString()
+	repo, err := parseRepoURL(repoUrl)
+	if err != nil {
+		diags.AddAttributeError(path.Root("repo_url"), "Invalid Repository URL", err.Error())
+		return diags
+	}
+	provider := config.RepoProvider.ValueString()
+	if config.RepoProvider.IsNull() {
+		provider, err = inferRepoProvider(repo)
+		if err != nil {
+			diags.AddAttributeError(path.Root("repo_provider"), "Unknown Repository Provider", err.Error())
+			return diags
+		}
+		plan.RepoProvider = types.StringValue(provider)
+	}
+	owner, slug, err := repoOwnerAndSlug(provider, repo)
+	if err != nil {
+		diags.AddAttributeError(path.Root("repo_url"), "Inconsistent Repository", err.Error())
+		return diags
+	}
+
+	derived := []struct {
+		name       string
+		value      string
+		configured types.String
+		planned    *types.String
+	}{
+		{"git_owner", owner, config.GitOwner, &plan.GitOwner},
+		{"git_repo_slug", slug, config.GitRepoSlug, &plan.GitRepoSlug},
+	}
+	for _, attr := range derived {
+		switch {
+		case attr.configured.IsNull() && attr.value == "":
+			diags.AddAttributeError(
+				path.Root(attr.name),
+				"Missing Repository Owner",
+				fmt.Sprintf("repo_url %q has no owner in its path, set %s.", repoUrl, attr.name),
+			)
+		case attr.configured.IsNull():
+			*attr.planned = types.StringValue(attr.value)
+		case attr.configured.IsUnknown() || provider == repoProviderCustom:
+		case !strings.EqualFold(attr.configured.ValueString(), attr.value):
+			diags.AddAttributeError(
+				path.Root(attr.name),
+				"Inconsistent Repository",
+				fmt.Sprintf("%s %q does not match %q of repo_url %q.", attr.name, attr.configured.ValueString(), attr.value, repoUrl),
+			)
+		}
+	}
+	return diags
+}
+
+func (r *AppResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   appResourceSchemaV0(ctx),
+			StateUpgrader: r.upgradeStateV0,
+		},
+	}
+}
+
+// appResourceSchemaV0 is the schema of bitrise_app before it was versioned. It
+// must not change anymore. States written before `id` and `timeouts` were
+// added lack them, and decode with both null.
+func appResourceSchemaV0(ctx context.Context) *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                schema.StringAttribute{Optional: true},
+			"token":             schema.StringAttribute{Optional: true, Sensitive: true},
+			"repo_provider":     schema.StringAttribute{Optional: true},
+			"is_public":         schema.BoolAttribute{Optional: true},
+			"organization_slug": schema.StringAttribute{Optional: true},
+			"repo_url":          schema.StringAttribute{Optional: true},
+			"type":              schema.StringAttribute{Optional: true},
+			"git_repo_slug":     schema.StringAttribute{Optional: true},
+			"git_owner":         schema.StringAttribute{Optional: true},
+			"title":             schema.StringAttribute{Optional: true},
+			"project_type":      schema.StringAttribute{Optional: true},
+			"stack_id":          schema.StringAttribute{Optional: true},
+			"config":            schema.StringAttribute{Optional: true},
+			"mode":              schema.StringAttribute{Optional: true},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// appResourceModelV0 describes the data model of appResourceSchemaV0.
+type appResourceModelV0 struct {
+	Id               types.String   `tfsdk:"id"`
+	Token            types.String   `tfsdk:"token"`
+	RepoProvider     types.String   `tfsdk:"repo_provider"`
+	IsPublic         types.Bool     `tfsdk:"is_public"`
+	OrganizationSlug types.String   `tfsdk:"organization_slug"`
+	RepoUrl          types.String   `tfsdk:"repo_url"`
+	Type             types.String   `tfsdk:"type"`
+	GitRepoSlug      types.String   `tfsdk:"git_repo_slug"`
+	GitOwner         types.String   `tfsdk:"git_owner"`
+	Title            types.String   `tfsdk:"title"`
+	ProjectType      types.String   `tfsdk:"project_type"`
+	StackID          types.String   `tfsdk:"stack_id"`
+	Config           types.String   `tfsdk:"config"`
+	Mode             types.String   `tfsdk:"mode"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+// upgradeStateV0 adds `abort_running_builds_on_destroy` and drops the resource
+// token when the provider has one. States without `id` keep it empty, Read
+// then looks the app up, as the provider may not be configured yet here.
+func (r *AppResource) upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior appResourceModelV0
+
+	// Read prior Terraform state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := AppResourceModel{
+		Id:               prior.Id,
+		Token:            prior.Token,
+		RepoProvider:     prior.RepoProvider,
+		IsPublic:         prior.IsPublic,
+		OrganizationSlug: prior.OrganizationSlug,
+		RepoUrl:          prior.RepoUrl,
+		Type:             prior.Type,
+		GitRepoSlug:      prior.GitRepoSlug,
+		GitOwner:         prior.GitOwner,
+		Title:            prior.Title,
+		ProjectType:      prior.ProjectType,
+		StackID:          prior.StackID,
+		Config:           prior.Config,
+		Mode:             prior.Mode,
+		AbortOnDestroy:   types.BoolValue(false),
+		Timeouts:         prior.Timeouts,
+	}
+	if r.client != nil && r.client.Token != "" {
+		data.Token = types.StringNull()
+	}
+
+	tflog.Debug(ctx, "upgraded bitrise_app state from version 0", map[string]interface{}{
+		"id": data.Id.ValueString(),
+	})
+
+	// Save upgraded data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findAppByRepo returns the single app of the organization registered for the
+// git repository repoSlug and, when title is not empty, titled exactly title.
+func findAppByRepo(ctx context.Context, client *BitriseClient, orgSlug, repoSlug, title string) (*App, error) {
+	query := url.Values{}
+	if title != "" {
+		query.Set("title", title)
+	}
+	apps, err := listApps(ctx, client, organizationAppsPath(orgSlug), query)
+	if err != nil {
+		return nil, err
+	}
+	var matches []App
+	for _, app := range apps {
+		if app.RepoSlug == repoSlug && (title == "" || app.Title == title) {
+			matches = append(matches, app)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no app of git repository %q titled %q in organization %q", repoSlug, title, orgSlug)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d apps of git repository %q titled %q in organization %q", len(matches), repoSlug, title, orgSlug)
+	}
 }
 
 func (r *AppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func register(a *AppResourceModel) (string, error) {
+// register is not idempotent, a repeated call registers a second app, so it is
+// only retried when the request cannot have reached the API.
+func register(ctx context.Context, client *BitriseClient, a *AppResourceModel) (string, error) {
 	respStruct := RegisterResponse{}
 	register := Register{
 		RepoProvider:     a.RepoProvider.ValueString(),
@@ -282,35 +787,56 @@ func register(a *AppResourceModel) (string, error) {
 		GitOwner:         a.GitOwner.ValueString(),
 		Title:            a.Title.ValueString(),
 	}
-	//resp.Diagnostics.AddError("Look here!", fmt.Sprintf("This is something: %s", register.OrganizationSlug))
-	marshalled, err := json.Marshal(register)
-	if err != nil {
-		return "error", err
-	}
-	request, err := http.NewRequest("POST", "https://api.bitrise.io/v0.1/apps/register", bytes.NewReader(marshalled))
+	err := client.do(ctx, http.MethodPost, "/apps/register", nil, register, &respStruct)
 	if err != nil {
 		return "error", err
 	}
-	request.Header.Set("Authorization", a.Token.ValueString())
-	request.Header.Set("Content-Type", "application/json")
-	client := &http.Client{Timeout: 10 * time.Second}
-	res, err := client.Do(request)
-	if err != nil {
-		return "error", err
-	}
-	body, err := ioutil.ReadAll(res.Body)
+	return respStruct.AppSlug, nil
+}
+
+func transferApp(ctx context.Context, client *BitriseClient, slug, orgSlug string) error {
+	return client.do(ctx, http.MethodPost, "/apps/"+url.PathEscape(slug)+"/transfer", nil, AppTransfer{OrganizationSlug: orgSlug}, nil)
+}
+
+// abortRunningBuilds aborts every in-progress build of the app and returns the
+// aborted builds. Builds finishing in the meantime are skipped.
+func abortRunningBuilds(ctx context.Context, client *BitriseClient, appSlug, reason string) ([]Build, error) {
+	running := url.Values{"status": []string{strconv.FormatInt(buildStatuses["in-progress"], 10)}}
+	builds, err := listBuilds(ctx, client, appSlug, running, math.MaxInt32)
 	if err != nil {
-		return "error", err
+		return nil, err
 	}
-	err = json.Unmarshal(body, &respStruct)
-	if err != nil {
-		return "error", err
+	var aborted []Build
+	for _, build := range builds {
+		abortPath := appBuildsPath(appSlug) + "/" + url.PathEscape(build.Slug) + "/abort"
+		err := client.do(withIdempotentRetries(ctx), http.MethodPost, abortPath, nil, BuildAbort{AbortReason: reason}, nil)
+		if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusBadRequest {
+			tflog.Debug(ctx, "build finished before it could be aborted", map[string]interface{}{"build": build.Slug})
+			continue
+		}
+		if err != nil {
+			return aborted, err
+		}
+		aborted = append(aborted, build)
 	}
-	defer res.Body.Close()
-	return respStruct.AppSlug, nil
+	return aborted, nil
 }
 
-func finish(a *AppResourceModel, slug string) (FinishResponse, error) {
+// requiresReplaceIfManaged replaces the app when an attribute only sent by
+// finish changes, since the API cannot update it. Imported apps have these
+// attributes null, which setting them does not replace.
+func requiresReplaceIfManaged() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = !req.StateValue.IsNull()
+		},
+		"The app is replaced when this changes, unless it was imported without it.",
+		"The app is replaced when this changes, unless it was imported without it.",
+	)
+}
+
+// finish only configures the registered app, so repeating it is safe.
+func finish(ctx context.Context, client *BitriseClient, a *AppResourceModel, slug string) (FinishResponse, error) {
 	respStruct := FinishResponse{}
 	finish := Finish{
 		ProjectType:      a.ProjectType.ValueString(),
@@ -319,30 +845,9 @@ func finish(a *AppResourceModel, slug string) (FinishResponse, error) {
 		OrganizationSlug: a.OrganizationSlug.ValueString(),
 		Mode:             a.Mode.ValueString(),
 	}
-	marshalled, err := json.Marshal(finish)
-	if err != nil {
-		return respStruct, err
-	}
-	url := "https://api.bitrise.io/v0.1/apps/" + slug + "/finish"
-	request, err := http.NewRequest("POST", url, bytes.NewReader(marshalled))
-	if err != nil {
-		return respStruct, err
-	}
-	request.Header.Set("Authorization", a.Token.ValueString())
-	request.Header.Set("Content-Type", "application/json")
-	client := &http.Client{Timeout: 10 * time.Second}
-	res, err := client.Do(request)
-	if err != nil {
-		return respStruct, err
-	}
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return respStruct, err
-	}
-	err = json.Unmarshal(body, &respStruct)
+	err := client.do(withIdempotentRetries(ctx), http.MethodPost, "/apps/"+url.PathEscape(slug)+"/finish", nil, finish, &respStruct)
 	if err != nil {
 		return respStruct, err
 	}
-	defer res.Body.Close()
 	return respStruct, nil
 }
@@ -0,0 +1,840 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAppResource(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAppDestroy(fake),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fake.providerConfig() + testAccAppResourceConfig("mobile", fakeOrgSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitrise_app.test", "id"),
+					resource.TestCheckResourceAttr("bitrise_app.test", "title", "mobile"),
+					resource.TestCheckResourceAttr("bitrise_app.test", "organization_slug", fakeOrgSlug),
+					resource.TestCheckResourceAttr("bitrise_app.test", "git_owner", "pgdevelopers"),
+					resource.TestCheckNoResourceAttr("bitrise_app.test", "token"),
+					testAccCheckFakeApp(fake, "bitrise_app.test", "mobile", fakeOrgSlug),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bitrise_app.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API never returns these back.
+				ImportStateVerifyIgnore: []string{"type", "title", "stack_id", "config", "mode", "timeouts"},
+			},
+			// Update and Read testing
+			{
+				Config: fake.providerConfig() + testAccAppResourceConfig("mobile-renamed", fakeOrgSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app.test", "title", "mobile-renamed"),
+					testAccCheckFakeApp(fake, "bitrise_app.test", "mobile-renamed", fakeOrgSlug),
+				),
+			},
+			// Transfer testing
+			{
+				Config: fake.providerConfig() + testAccAppResourceConfig("mobile-renamed", fakeOtherOrgSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app.test", "organization_slug", fakeOtherOrgSlug),
+					testAccCheckFakeApp(fake, "bitrise_app.test", "mobile-renamed", fakeOtherOrgSlug),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// TestAccAppResource_visibilityAndStack checks that is_public is updated in
+// place, and that the attributes only sent when registering the app replace
+// it.
+func TestAccAppResource_visibilityAndStack(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAppDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccAppResourceStackConfig(false, "osx-xcode-14.2.x-ventura"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app.test", "is_public", "false"),
+				),
+			},
+			{
+				Config: fake.providerConfig() + testAccAppResourceStackConfig(true, "osx-xcode-14.2.x-ventura"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bitrise_app.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app.test", "is_public", "true"),
+					func(s *terraform.State) error {
+						slug := s.RootModule().Resources["bitrise_app.test"].Primary.ID
+						if !fake.App(slug).IsPublic {
+							return fmt.Errorf("expected app %s to be public", slug)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: fake.providerConfig() + testAccAppResourceStackConfig(true, "osx-xcode-15.0.x-ventura"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("bitrise_app.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app.test", "stack_id", "osx-xcode-15.0.x-ventura"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAppResource_transferWithoutRights(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAppDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + testAccAppResourceConfig("mobile", fakeOrgSlug),
+			},
+			{
+				Config:      fake.providerConfig() + testAccAppResourceConfig("mobile", fakeRestrictedOrgSlug),
+				ExpectError: regexp.MustCompile(`Insufficient Rights in Target Organization`),
+			},
+		},
+	})
+}
+
+// TestAccAppResource_failedFinish checks that an app whose registration
+// cannot be finished is tracked and replaced, not left behind.
+func TestAccAppResource_failedFinish(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAppDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { fake.FailNext("POST /apps/{app}/finish", http.StatusUnprocessableEntity) },
+				Config:      fake.providerConfig() + testAccAppResourceConfig("mobile", fakeOrgSlug),
+				ExpectError: regexp.MustCompile(`Unable to finish registering App`),
+			},
+			{
+				Config: fake.providerConfig() + testAccAppResourceConfig("mobile", fakeOrgSlug),
+				Check: func(s *terraform.State) error {
+					if count := fake.AppCount(); count != 1 {
+						return fmt.Errorf("expected the unfinished app to be replaced, got %d apps", count)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// TestAccAppResource_resourceToken covers the deprecated resource level
+// token, used when the provider has none.
+func TestAccAppResource_resourceToken(t *testing.T) {
+	fake := newFakeBitrise(t)
+	t.Setenv("BITRISE_TOKEN", "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAppDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "bitrise" {
+  endpoint = %[1]q
+}
+
+resource "bitrise_app" "test" {
+  token         = %[2]q
+  repo_url      = "git@github.com:pgdevelopers/mobile.git"
+  git_repo_slug = "mobile"
+  project_type  = "react-native"
+  stack_id      = "osx-xcode-14.2.x-ventura"
+  config        = "default-react-native-config"
+}
+`, fake.server.URL, fakeBitriseToken),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitrise_app.test", "id"),
+					resource.TestCheckResourceAttr("bitrise_app.test", "token", fakeBitriseToken),
+				),
+			},
+		},
+	})
+}
+
+// TestAccAppResource_providerToken checks that no token is stored in the state
+// when the provider has one.
+func TestAccAppResource_providerToken(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAppDestroy(fake),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+resource "bitrise_app" "test" {
+  token         = %[1]q
+  repo_url      = "git@github.com:pgdevelopers/mobile.git"
+  project_type  = "react-native"
+  stack_id      = "osx-xcode-14.2.x-ventura"
+  config        = "default-react-native-config"
+}
+`, fakeBitriseToken),
+				ExpectError: regexp.MustCompile(`Conflicting Bitrise Access Tokens`),
+			},
+			{
+				Config: fake.providerConfig() + testAccAppResourceConfig("mobile", fakeOrgSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitrise_app.test", "id"),
+					resource.TestCheckNoResourceAttr("bitrise_app.test", "token"),
+				),
+			},
+		},
+	})
+}
+
+// TestAppResourceRead_storedToken checks that Read drops a token stored before
+// the provider had one.
+func TestAppResourceRead_storedToken(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", RepoSlug: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+
+	server, stateType := newTestAppResourceServer(t, fake)
+	resp, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName: "bitrise_app",
+		CurrentState: testAppResourceValue(t, stateType, map[string]tftypes.Value{
+			"id":    tftypes.NewValue(tftypes.String, app.Slug),
+			"token": tftypes.NewValue(tftypes.String, "old-token"),
+			"title": tftypes.NewValue(tftypes.String, "mobile"),
+		}),
+	})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("unable to read state: %v %v", err, diagnosticsSummary(resp.Diagnostics))
+	}
+	attributes := testAppResourceAttributes(t, stateType, resp.NewState)
+	if !attributes["token"].IsNull() {
+		t.Errorf("expected the resource token to be dropped, got %s", attributes["token"])
+	}
+}
+
+func TestAccAppResource_abortRunningBuildsOnDestroy(t *testing.T) {
+	fake := newFakeBitrise(t)
+	var appSlug string
+	var running, finished []*Build
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: resource.ComposeAggregateTestCheckFunc(
+			testAccCheckAppDestroy(fake),
+			func(s *terraform.State) error {
+				for _, build := range running {
+					aborted := fake.Build(appSlug, build.Slug)
+					if aborted.StatusText != "aborted" || aborted.AbortReason != abortOnDestroyReason {
+						return fmt.Errorf("expected build %s to be aborted, got %q (%q)", build.Slug, aborted.StatusText, aborted.AbortReason)
+					}
+				}
+				for _, build := range finished {
+					if kept := fake.Build(appSlug, build.Slug); kept.StatusText != "success" {
+						return fmt.Errorf("expected build %s to be kept, got %q", build.Slug, kept.StatusText)
+					}
+				}
+				return nil
+			},
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+resource "bitrise_app" "test" {
+  repo_url                        = "git@github.com:pgdevelopers/mobile.git"
+  git_repo_slug                   = "mobile"
+  project_type                    = "react-native"
+  stack_id                        = "osx-xcode-14.2.x-ventura"
+  config                          = "default-react-native-config"
+  abort_running_builds_on_destroy = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app.test", "abort_running_builds_on_destroy", "true"),
+					// Start builds on the new app, more than a page of them.
+					func(s *terraform.State) error {
+						appSlug = s.RootModule().Resources["bitrise_app.test"].Primary.ID
+						finished = append(finished, fake.AddBuild(appSlug, Build{Status: 1, StatusText: "success"}))
+						for i := 0; i < 3; i++ {
+							running = append(running, fake.AddBuild(appSlug, Build{Status: 0, StatusText: "in-progress"}))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAppResource_repoProviders(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckAppDestroy(fake),
+		Steps: []resource.TestStep{
+			// Owner and name are taken from the URL, including GitLab subgroups
+			{
+				Config: fake.providerConfig() + testAccAppResourceRepoConfig("gitlab-self-hosted", "git@gitlab.example.com:pg/apps/mobile.git", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app.test", "repo_provider", "gitlab-self-hosted"),
+					resource.TestCheckResourceAttr("bitrise_app.test", "git_owner", "pg/apps"),
+					resource.TestCheckResourceAttr("bitrise_app.test", "git_repo_slug", "mobile"),
+				),
+			},
+			// Moving the repository updates the app in place
+			{
+				Config: fake.providerConfig() + testAccAppResourceRepoConfig("bitbucket-server", "https://git.example.com/scm/mob/mobile-app.git", "MOB"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app.test", "repo_provider", "bitbucket-server"),
+					resource.TestCheckResourceAttr("bitrise_app.test", "git_owner", "MOB"),
+					resource.TestCheckResourceAttr("bitrise_app.test", "git_repo_slug", "mobile-app"),
+					func(s *terraform.State) error {
+						app := fake.App(s.RootModule().Resources["bitrise_app.test"].Primary.ID)
+						if app.Provider != "bitbucket-server" || app.RepoOwner != "MOB" || app.RepoSlug != "mobile-app" {
+							return fmt.Errorf("expected the app repository to be updated, got %s %s/%s", app.Provider, app.RepoOwner, app.RepoSlug)
+						}
+						return nil
+					},
+				),
+			},
+			// Custom repositories may have no owner in their URL
+			{
+				Config:      fake.providerConfig() + testAccAppResourceRepoConfig("custom", "https://git.example.com/mobile.git", ""),
+				ExpectError: regexp.MustCompile(`Missing Repository Owner`),
+			},
+			{
+				Config: fake.providerConfig() + testAccAppResourceRepoConfig("custom", "https://git.example.com/mobile.git", "pg"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app.test", "repo_provider", "custom"),
+					resource.TestCheckResourceAttr("bitrise_app.test", "git_owner", "pg"),
+					resource.TestCheckResourceAttr("bitrise_app.test", "git_repo_slug", "mobile"),
+				),
+			},
+			{
+				Config:      fake.providerConfig() + testAccAppResourceRepoConfig("bitbucket-server", "https://git.example.com/scm/mob/mobile-app.git", "WEB"),
+				ExpectError: regexp.MustCompile(`Inconsistent Repository`),
+			},
+			{
+				Config:      fake.providerConfig() + testAccAppResourceRepoConfig("github", "https://gitlab.com/pg/mobile.git", ""),
+				ExpectError: regexp.MustCompile(`Inconsistent Repository`),
+			},
+			{
+				Config:      fake.providerConfig() + testAccAppResourceRepoConfig("", "git@git.example.com:pg/mobile.git", ""),
+				ExpectError: regexp.MustCompile(`Unknown Repository Provider`),
+			},
+		},
+	})
+}
+
+// TestAccAppResource_recorded replays the app lifecycle recorded in
+// testdata/cassettes/app_resource.json. The cassette was recorded against the
+// fake API, so it pins the requests the provider sends rather than the
+// responses of the real API. Run it with BITRISE_RECORDER_MODE=record to
+// record it again, against the real API when BITRISE_TOKEN is set and against
+// the fake API otherwise.
+func TestAccAppResource_recorded(t *testing.T) {
+	cassettePath := filepath.Join("testdata", "cassettes", "app_resource.json")
+	t.Setenv(recorderCassetteEnvVar, cassettePath)
+	t.Cleanup(func() { forgetCassette(cassettePath) })
+	if os.Getenv(recorderModeEnvVar) == recorderModeRecord {
+		if err := os.Remove(cassettePath); err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+	}
+
+	providerConfig := `
+provider "bitrise" {
+  token = "replayed-token"
+}
+`
+	switch {
+	case os.Getenv(recorderModeEnvVar) == "":
+		t.Setenv(recorderModeEnvVar, recorderModeReplay)
+	case os.Getenv("BITRISE_TOKEN") != "":
+		providerConfig = ""
+	default:
+		providerConfig = newFakeBitrise(t).providerConfig()
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccAppResourceRecordedConfig("tf-acc-recorded"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitrise_app.test", "id"),
+					resource.TestCheckResourceAttr("bitrise_app.test", "title", "tf-acc-recorded"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "bitrise_app.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"type", "title", "stack_id", "config", "mode", "timeouts"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccAppResourceRecordedConfig("tf-acc-recorded-renamed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_app.test", "title", "tf-acc-recorded-renamed"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAppResourceUpgradeStateV0(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", RepoSlug: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	fake.AddApp(App{Title: "mobile-legacy", RepoSlug: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	fake.AddApp(App{Title: "mobile", RepoSlug: "mobile", Owner: AppOwner{Slug: fakeOtherOrgSlug}})
+
+	ctx := context.Background()
+	server, stateType := newTestAppResourceServer(t, fake)
+
+	// State written before `id` and `timeouts` were added.
+	rawState := []byte(`{
+		"token": "old-token",
+		"repo_provider": "github",
+		"is_public": false,
+		"organization_slug": "` + fakeOrgSlug + `",
+		"repo_url": "git@github.com:pgdevelopers/mobile.git",
+		"type": "git",
+		"git_repo_slug": "mobile",
+		"git_owner": "pgdevelopers",
+		"title": "mobile",
+		"project_type": "flutter",
+		"stack_id": "osx-xcode-14.2.x-ventura",
+		"config": "flutter-config-test-app-both",
+		"mode": "manual"
+	}`)
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "bitrise_app",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: rawState},
+	})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("unable to upgrade state: %v %v", err, diagnosticsSummary(resp.Diagnostics))
+	}
+
+	attributes := testAppResourceAttributes(t, stateType, resp.UpgradedState)
+	if !attributes["token"].IsNull() {
+		t.Errorf("expected the resource token to be dropped, got %s", attributes["token"])
+	}
+	var abortOnDestroy bool
+	if err := attributes["abort_running_builds_on_destroy"].As(&abortOnDestroy); err != nil || abortOnDestroy {
+		t.Errorf("expected abort_running_builds_on_destroy to default to false, got %s", attributes["abort_running_builds_on_destroy"])
+	}
+
+	// Read looks the app up by its repository and title.
+	readResp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "bitrise_app",
+		CurrentState: resp.UpgradedState,
+	})
+	if err != nil || len(readResp.Diagnostics) > 0 {
+		t.Fatalf("unable to read state: %v %v", err, diagnosticsSummary(readResp.Diagnostics))
+	}
+	attributes = testAppResourceAttributes(t, stateType, readResp.NewState)
+	var id string
+	if err := attributes["id"].As(&id); err != nil || id != app.Slug {
+		t.Errorf("expected id %q, got %q (%v)", app.Slug, id, err)
+	}
+}
+
+// TestAppResourceUpgradeStateV0_unconfigured checks that a state upgrades
+// before the provider is configured, keeping the token it cannot replace.
+func TestAppResourceUpgradeStateV0_unconfigured(t *testing.T) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateType := schemaResp.ResourceSchemas["bitrise_app"].ValueType()
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "bitrise_app",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(`{
+			"token": "old-token",
+			"organization_slug": "` + fakeOrgSlug + `",
+			"repo_url": "git@github.com:pgdevelopers/mobile.git",
+			"git_repo_slug": "mobile",
+			"title": "mobile"
+		}`)},
+	})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("unable to upgrade state: %v %v", err, diagnosticsSummary(resp.Diagnostics))
+	}
+
+	attributes := testAppResourceAttributes(t, stateType, resp.UpgradedState)
+	var token string
+	if err := attributes["token"].As(&token); err != nil || token != "old-token" {
+		t.Errorf("expected the resource token to be kept, got %q (%v)", token, err)
+	}
+	if !attributes["id"].IsNull() {
+		t.Errorf("expected id to be left to Read, got %s", attributes["id"])
+	}
+}
+
+// TestAppResourceUpdate_transferThenFailedUpdate checks that a transfer is
+// kept in the state when the update following it fails, so the next apply
+// does not transfer the app again.
+func TestAppResourceUpdate_transferThenFailedUpdate(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", RepoSlug: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	fake.FailNext("PATCH /apps/{app}", http.StatusUnprocessableEntity)
+
+	server, stateType := newTestAppResourceServer(t, fake)
+	prior := map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, app.Slug),
+		"organization_slug": tftypes.NewValue(tftypes.String, fakeOrgSlug),
+		"title":             tftypes.NewValue(tftypes.String, "mobile"),
+		"repo_url":          tftypes.NewValue(tftypes.String, "git@github.com:pgdevelopers/mobile.git"),
+	}
+	planned := map[string]tftypes.Value{}
+	for name, value := range prior {
+		planned[name] = value
+	}
+	planned["organization_slug"] = tftypes.NewValue(tftypes.String, fakeOtherOrgSlug)
+	planned["title"] = tftypes.NewValue(tftypes.String, "mobile-renamed")
+
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "bitrise_app",
+		PriorState:   testAppResourceValue(t, stateType, prior),
+		PlannedState: testAppResourceValue(t, stateType, planned),
+		Config:       testAppResourceValue(t, stateType, planned),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) == 0 {
+		t.Fatal("expected the failed update to be reported")
+	}
+
+	if owner := fake.App(app.Slug).Owner.Slug; owner != fakeOtherOrgSlug {
+		t.Fatalf("expected the app to be transferred, got organization %s", owner)
+	}
+	attributes := testAppResourceAttributes(t, stateType, resp.NewState)
+	var orgSlug, title string
+	if err := attributes["organization_slug"].As(&orgSlug); err != nil || orgSlug != fakeOtherOrgSlug {
+		t.Errorf("expected organization_slug %q in the state, got %q (%v)", fakeOtherOrgSlug, orgSlug, err)
+	}
+	if err := attributes["title"].As(&title); err != nil || title != "mobile" {
+		t.Errorf("expected the failed title update to stay out of the state, got %q (%v)", title, err)
+	}
+}
+
+// TestAppResourceDelete_partialAbort checks that the builds aborted before an
+// abort fails are still reported.
+func TestAppResourceDelete_partialAbort(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", RepoSlug: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	for i := 0; i < 2; i++ {
+		fake.AddBuild(app.Slug, Build{Status: 0, StatusText: "in-progress"})
+	}
+	fake.FailAfter("POST /apps/{app}/builds/{build}/abort", 1, http.StatusInternalServerError)
+
+	server, stateType := newTestAppResourceServer(t, fake)
+	prior := testAppResourceValue(t, stateType, map[string]tftypes.Value{
+		"id":                              tftypes.NewValue(tftypes.String, app.Slug),
+		"organization_slug":               tftypes.NewValue(tftypes.String, fakeOrgSlug),
+		"repo_url":                        tftypes.NewValue(tftypes.String, "git@github.com:pgdevelopers/mobile.git"),
+		"abort_running_builds_on_destroy": tftypes.NewValue(tftypes.Bool, true),
+	})
+	destroyed, err := tfprotov6.NewDynamicValue(stateType, tftypes.NewValue(stateType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "bitrise_app",
+		PriorState:   prior,
+		PlannedState: &destroyed,
+		Config:       &destroyed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var warned, failed bool
+	for _, diagnostic := range resp.Diagnostics {
+		switch {
+		case diagnostic.Severity == tfprotov6.DiagnosticSeverityWarning && diagnostic.Summary == "Running Builds Aborted":
+			warned = true
+		case diagnostic.Severity == tfprotov6.DiagnosticSeverityError:
+			failed = true
+		}
+	}
+	if !warned || !failed {
+		t.Errorf("expected the aborted build and the failure to be reported, got %s", diagnosticsSummary(resp.Diagnostics))
+	}
+	if fake.App(app.Slug) == nil {
+		t.Error("expected the app to be kept when aborting its builds fails")
+	}
+}
+
+func TestFindAppByRepo(t *testing.T) {
+	fake := newFakeBitrise(t)
+	mobile := fake.AddApp(App{Title: "mobile", RepoSlug: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	fake.AddApp(App{Title: "mobile-legacy", RepoSlug: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	web := fake.AddApp(App{Title: "web", RepoSlug: "web", Owner: AppOwner{Slug: fakeOrgSlug}})
+
+	client := &BitriseClient{HTTPClient: fake.server.Client(), Endpoint: fake.server.URL, Token: fakeBitriseToken}
+
+	testCases := map[string]struct {
+		repoSlug string
+		title    string
+		want     string
+		wantErr  bool
+	}{
+		"title":          {repoSlug: "mobile", title: "mobile", want: mobile.Slug},
+		"repo only":      {repoSlug: "web", want: web.Slug},
+		"ambiguous repo": {repoSlug: "mobile", wantErr: true},
+		"wrong repo":     {repoSlug: "web", title: "mobile", wantErr: true},
+		"missing":        {repoSlug: "desktop", wantErr: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			app, err := findAppByRepo(context.Background(), client, fakeOrgSlug, testCase.repoSlug, testCase.title)
+			if testCase.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got app %s", app.Slug)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if app.Slug != testCase.want {
+				t.Errorf("expected app %s, got %s", testCase.want, app.Slug)
+			}
+		})
+	}
+}
+
+// newTestAppResourceServer returns the provider protocol server configured
+// against the fake API, and the type of bitrise_app states.
+func newTestAppResourceServer(t *testing.T, fake *fakeBitrise) (tfprotov6.ProviderServer, tftypes.Type) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"endpoint":        tftypes.String,
+		"token":           tftypes.String,
+		"validate_token":  tftypes.Bool,
+		"max_retries":     tftypes.Number,
+		"retry_max_wait":  tftypes.String,
+		"request_timeout": tftypes.String,
+	}}
+	config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, map[string]tftypes.Value{
+		"endpoint":        tftypes.NewValue(tftypes.String, fake.server.URL),
+		"token":           tftypes.NewValue(tftypes.String, fakeBitriseToken),
+		"validate_token":  tftypes.NewValue(tftypes.Bool, nil),
+		"max_retries":     tftypes.NewValue(tftypes.Number, 0),
+		"retry_max_wait":  tftypes.NewValue(tftypes.String, nil),
+		"request_timeout": tftypes.NewValue(tftypes.String, nil),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil || len(configureResp.Diagnostics) > 0 {
+		t.Fatalf("unable to configure provider: %v %v", err, diagnosticsSummary(configureResp.Diagnostics))
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, schemaResp.ResourceSchemas["bitrise_app"].ValueType()
+}
+
+// testAppResourceValue returns a bitrise_app value of stateType with the given
+// attributes, the others being null.
+func testAppResourceValue(t *testing.T, stateType tftypes.Type, attributes map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range stateType.(tftypes.Object).AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		}
+	}
+	value, err := tfprotov6.NewDynamicValue(stateType, tftypes.NewValue(stateType, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &value
+}
+
+// testAppResourceAttributes returns the attributes of a bitrise_app value.
+func testAppResourceAttributes(t *testing.T, stateType tftypes.Type, value *tfprotov6.DynamicValue) map[string]tftypes.Value {
+	decoded, err := value.Unmarshal(stateType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := decoded.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	return attributes
+}
+
+// testAccCheckFakeApp verifies the app as stored by the fake API.
+func testAccCheckFakeApp(fake *fakeBitrise, name, title, orgSlug string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		app := fake.App(rs.Primary.ID)
+		if app == nil {
+			return fmt.Errorf("app %s does not exist", rs.Primary.ID)
+		}
+		if app.Title != title {
+			return fmt.Errorf("expected title %q, got %q", title, app.Title)
+		}
+		if app.Owner.Slug != orgSlug {
+			return fmt.Errorf("expected organization %q, got %q", orgSlug, app.Owner.Slug)
+		}
+		if app.Status != 1 {
+			return fmt.Errorf("app %s was never finished", app.Slug)
+		}
+		return nil
+	}
+}
+
+func testAccCheckAppDestroy(fake *fakeBitrise) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "bitrise_app" {
+				continue
+			}
+			if fake.App(rs.Primary.ID) != nil {
+				return fmt.Errorf("app %s still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccAppResourceConfig(title, orgSlug string) string {
+	return fmt.Sprintf(`
+resource "bitrise_app" "test" {
+  repo_url          = "git@github.com:pgdevelopers/mobile.git"
+  git_repo_slug     = "mobile"
+  title             = %[1]q
+  organization_slug = %[2]q
+  project_type      = "react-native"
+  stack_id          = "osx-xcode-14.2.x-ventura"
+  config            = "default-react-native-config"
+}
+`, title, orgSlug)
+}
+
+func testAccAppResourceStackConfig(isPublic bool, stackID string) string {
+	return fmt.Sprintf(`
+resource "bitrise_app" "test" {
+  repo_url      = "git@github.com:pgdevelopers/mobile.git"
+  is_public     = %[1]t
+  project_type  = "react-native"
+  stack_id      = %[2]q
+  config        = "default-react-native-config"
+}
+`, isPublic, stackID)
+}
+
+func testAccAppResourceRepoConfig(repoProvider, repoUrl, gitOwner string) string {
+	optional := ""
+	if repoProvider != "" {
+		optional += fmt.Sprintf("  repo_provider = %q\n", repoProvider)
+	}
+	if gitOwner != "" {
+		optional += fmt.Sprintf("  git_owner     = %q\n", gitOwner)
+	}
+	return fmt.Sprintf(`
+resource "bitrise_app" "test" {
+%[1]s  repo_url      = %[2]q
+  project_type  = "react-native"
+  stack_id      = "osx-xcode-14.2.x-ventura"
+  config        = "default-react-native-config"
+}
+`, optional, repoUrl)
+}
+
+func testAccAppResourceRecordedConfig(title string) string {
+	return fmt.Sprintf(`
+resource "bitrise_app" "test" {
+  repo_url      = "git@github.com:pgdevelopers/terraform-provider-bitrise.git"
+  git_repo_slug = "terraform-provider-bitrise"
+  title         = %[1]q
+  project_type  = "other"
+  stack_id      = "linux-docker-android-20.04"
+  config        = "other-config"
+}
+`, title)
+}
+
+func diagnosticsSummary(diagnostics []*tfprotov6.Diagnostic) []string {
+	var summary []string
+	for _, diagnostic := range diagnostics {
+		summary = append(summary, diagnostic.Summary+": "+diagnostic.Detail)
+	}
+	return summary
+}
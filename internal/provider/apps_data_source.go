@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AppsDataSource{}
+
+func NewAppsDataSource() datasource.DataSource {
+	return &AppsDataSource{}
+}
+
+// AppsDataSource defines the data source implementation.
+type AppsDataSource struct {
+	client *BitriseClient
+}
+
+// AppsDataSourceModel describes the data source data model.
+type AppsDataSourceModel struct {
+	Id               types.String    `tfsdk:"id"`
+	OrganizationSlug types.String    `tfsdk:"organization_slug"`
+	OwnerSlug        types.String    `tfsdk:"owner_slug"`
+	ProjectType      types.String    `tfsdk:"project_type"`
+	TitleRegex       types.String    `tfsdk:"title_regex"`
+	RepoProvider     types.String    `tfsdk:"repo_provider"`
+	IsDisabled       types.Bool      `tfsdk:"is_disabled"`
+	Apps             []AppsItemModel `tfsdk:"apps"`
+}
+
+// AppsItemModel describes a single app of the apps data source.
+type AppsItemModel struct {
+	Slug             types.String `tfsdk:"slug"`
+	Title            types.String `tfsdk:"title"`
+	OrganizationSlug types.String `tfsdk:"organization_slug"`
+	RepoProvider     types.String `tfsdk:"repo_provider"`
+	IsPublic         types.Bool   `tfsdk:"is_public"`
+	IsDisabled       types.Bool   `tfsdk:"is_disabled"`
+	RepoUrl          types.String `tfsdk:"repo_url"`
+	GitRepoSlug      types.String `tfsdk:"git_repo_slug"`
+	GitOwner         types.String `tfsdk:"git_owner"`
+	ProjectType      types.String `tfsdk:"project_type"`
+	Status           types.Int64  `tfsdk:"status"`
+	AvatarUrl        types.String `tfsdk:"avatar_url"`
+}
+
+// appFilter holds the client side filters of the apps data source.
+type appFilter struct {
+	ownerSlug    string
+	projectType  string
+	titleRegex   *regexp.Regexp
+	repoProvider string
+	isDisabled   *bool
+}
+
+func (f appFilter) match(app App) bool {
+	if f.ownerSlug != "" && app.Owner.Slug != f.ownerSlug {
+		return false
+	}
+	if f.projectType != "" && app.ProjectType != f.projectType {
+		return false
+	}
+	if f.titleRegex != nil && !f.titleRegex.MatchString(app.Title) {
+		return false
+	}
+	if f.repoProvider != "" && app.Provider != f.repoProvider {
+		return false
+	}
+	if f.isDisabled != nil && app.IsDisabled != *f.isDisabled {
+		return false
+	}
+	return true
+}
+
+func (d *AppsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apps"
+}
+
+func (d *AppsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the apps accessible with the configured token, following pagination transparently.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the listing",
+			},
+			"organization_slug": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list apps of this organization",
+			},
+			"owner_slug": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return apps owned by this user or organization slug",
+			},
+			"project_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return apps of this project type",
+			},
+			"title_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return apps whose title matches this regular expression",
+			},
+			"repo_provider": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return apps hosted on this repo provider",
+			},
+			"is_disabled": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return disabled (`true`) or enabled (`false`) apps",
+			},
+			"apps": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Apps matching the filters",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"slug": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SLUG of the app",
+						},
+						"title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Title of the app",
+						},
+						"organization_slug": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SLUG of the owner of the app",
+						},
+						"repo_provider": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Repo provider",
+						},
+						"is_public": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Is the app public or private",
+						},
+						"is_disabled": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Is the app disabled",
+						},
+						"repo_url": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "URL for the git repository",
+						},
+						"git_repo_slug": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the git repository",
+						},
+						"git_owner": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Owner of the git repository",
+						},
+						"project_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Project type of the app",
+						},
+						"status": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Status of the app",
+						},
+						"avatar_url": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "URL of the app avatar",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AppsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AppsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := appFilter{
+		ownerSlug:    data.OwnerSlug.ValueString(),
+		projectType:  data.ProjectType.ValueString(),
+		repoProvider: data.RepoProvider.ValueString(),
+	}
+	if !data.TitleRegex.IsNull() {
+		re, err := regexp.Compile(data.TitleRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("title_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+		filter.titleRegex = re
+	}
+	if !data.IsDisabled.IsNull() {
+		isDisabled := data.IsDisabled.ValueBool()
+		filter.isDisabled = &isDisabled
+	}
+
+	query := url.Values{"sort_by": []string{"created_at"}}
+	if filter.projectType != "" {
+		query.Set("project_type", filter.projectType)
+	}
+
+	listPath := "/apps"
+	id := "all"
+	if !data.OrganizationSlug.IsNull() {
+		listPath = organizationAppsPath(data.OrganizationSlug.ValueString())
+		id = data.OrganizationSlug.ValueString()
+	}
+
+	apps, err := listApps(ctx, d.client, listPath, query)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list apps, got error: %s", err))
+		return
+	}
+
+	data.Apps = []AppsItemModel{}
+	for _, app := range apps {
+		if !filter.match(app) {
+			continue
+		}
+		data.Apps = append(data.Apps, AppsItemModel{
+			Slug:             types.StringValue(app.Slug),
+			Title:            types.StringValue(app.Title),
+			OrganizationSlug: types.StringValue(app.Owner.Slug),
+			RepoProvider:     types.StringValue(app.Provider),
+			IsPublic:         types.BoolValue(app.IsPublic),
+			IsDisabled:       types.BoolValue(app.IsDisabled),
+			RepoUrl:          types.StringValue(app.RepoUrl),
+			GitRepoSlug:      types.StringValue(app.RepoSlug),
+			GitOwner:         types.StringValue(app.RepoOwner),
+			ProjectType:      types.StringValue(app.ProjectType),
+			Status:           types.Int64Value(app.Status),
+			AvatarUrl:        types.StringValue(app.AvatarUrl),
+		})
+	}
+	data.Id = types.StringValue(id)
+
+	tflog.Trace(ctx, "read an apps data source", map[string]interface{}{"count": len(data.Apps)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAppFilterMatch(t *testing.T) {
+	disabled := true
+	app := App{
+		Title:       "mobile-ios",
+		ProjectType: "ios",
+		Provider:    "github",
+		IsDisabled:  true,
+		Owner:       AppOwner{Slug: "org"},
+	}
+
+	testCases := map[string]struct {
+		filter appFilter
+		want   bool
+	}{
+		"empty":               {filter: appFilter{}, want: true},
+		"owner match":         {filter: appFilter{ownerSlug: "org"}, want: true},
+		"owner mismatch":      {filter: appFilter{ownerSlug: "other"}, want: false},
+		"project type":        {filter: appFilter{projectType: "android"}, want: false},
+		"title regex match":   {filter: appFilter{titleRegex: regexp.MustCompile("^mobile-")}, want: true},
+		"title regex miss":    {filter: appFilter{titleRegex: regexp.MustCompile("^web-")}, want: false},
+		"repo provider":       {filter: appFilter{repoProvider: "gitlab"}, want: false},
+		"disabled match":      {filter: appFilter{isDisabled: &disabled}, want: true},
+		"all filters at once": {filter: appFilter{ownerSlug: "org", projectType: "ios", repoProvider: "github", isDisabled: &disabled}, want: true},
+	}
+
+	for name, testCase := range testCases {
+		if got := testCase.filter.match(app); got != testCase.want {
+			t.Errorf("%s: expected %t, got %t", name, testCase.want, got)
+		}
+	}
+}
+
+func TestAccAppsDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+	fake.AddApp(App{Title: "mobile-ios", ProjectType: "ios", Owner: AppOwner{Slug: fakeOrgSlug}})
+	fake.AddApp(App{Title: "mobile-android", ProjectType: "android", Owner: AppOwner{Slug: fakeOrgSlug}})
+	fake.AddApp(App{Title: "web", ProjectType: "web", Owner: AppOwner{Slug: fakeOrgSlug}})
+	fake.AddApp(App{Title: "mobile-tools", ProjectType: "other", Owner: AppOwner{Slug: fakeOtherOrgSlug}})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_apps" "test" {
+  organization_slug = %q
+  title_regex       = "^mobile-"
+}
+`, fakeOrgSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_apps.test", "apps.#", "2"),
+					resource.TestCheckResourceAttr("data.bitrise_apps.test", "apps.0.title", "mobile-ios"),
+					resource.TestCheckResourceAttr("data.bitrise_apps.test", "apps.1.title", "mobile-android"),
+				),
+			},
+		},
+	})
+}
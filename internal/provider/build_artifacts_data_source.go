@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BuildArtifactsDataSource{}
+
+func NewBuildArtifactsDataSource() datasource.DataSource {
+	return &BuildArtifactsDataSource{}
+}
+
+// BuildArtifactsDataSource defines the data source implementation.
+type BuildArtifactsDataSource struct {
+	client *BitriseClient
+}
+
+type BuildArtifact struct {
+	Slug                 string `json:"slug"`
+	Title                string `json:"title"`
+	ArtifactType         string `json:"artifact_type"`
+	FileSizeBytes        int64  `json:"file_size_bytes"`
+	IsPublicPageEnabled  bool   `json:"is_public_page_enabled"`
+	ExpiringDownloadUrl  string `json:"expiring_download_url"`
+	PublicInstallPageUrl string `json:"public_install_page_url"`
+}
+
+type buildArtifactResponse struct {
+	Data BuildArtifact `json:"data"`
+}
+
+type buildArtifactListResponse struct {
+	Data   []BuildArtifact `json:"data"`
+	Paging Paging          `json:"paging"`
+}
+
+// BuildArtifactsDataSourceModel describes the data source data model.
+type BuildArtifactsDataSourceModel struct {
+	Id           types.String              `tfsdk:"id"`
+	AppSlug      types.String              `tfsdk:"app_slug"`
+	BuildSlug    types.String              `tfsdk:"build_slug"`
+	ArtifactType types.String              `tfsdk:"artifact_type"`
+	Artifacts    []BuildArtifactsItemModel `tfsdk:"artifacts"`
+}
+
+// BuildArtifactsItemModel describes a single artifact of the build artifacts
+// data source.
+type BuildArtifactsItemModel struct {
+	Slug                 types.String `tfsdk:"slug"`
+	Title                types.String `tfsdk:"title"`
+	ArtifactType         types.String `tfsdk:"artifact_type"`
+	FileSizeBytes        types.Int64  `tfsdk:"file_size_bytes"`
+	IsPublicPageEnabled  types.Bool   `tfsdk:"is_public_page_enabled"`
+	DownloadUrl          types.String `tfsdk:"download_url"`
+	PublicInstallPageUrl types.String `tfsdk:"public_install_page_url"`
+}
+
+func (d *BuildArtifactsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_build_artifacts"
+}
+
+func (d *BuildArtifactsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Artifacts of a build, such as the IPA or APK it produced, with their download URLs.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the build",
+			},
+			"app_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG of the app",
+			},
+			"build_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG of the build",
+			},
+			"artifact_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return artifacts of this type, e.g. `ios-ipa`, `android-apk` or `file`",
+			},
+			"artifacts": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Artifacts of the build",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"slug": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SLUG of the artifact",
+						},
+						"title": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "File name of the artifact",
+						},
+						"artifact_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the artifact",
+						},
+						"file_size_bytes": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Size of the artifact in bytes",
+						},
+						"is_public_page_enabled": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the artifact has a public install page",
+						},
+						"download_url": schema.StringAttribute{
+							Computed:            true,
+							Sensitive:           true,
+							MarkdownDescription: "Expiring URL to download the artifact. Anyone with the URL can download it until it expires.",
+						},
+						"public_install_page_url": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "URL of the public install page, empty when it is disabled",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BuildArtifactsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BuildArtifactsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BuildArtifactsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	artifactsPath := buildArtifactsPath(data.AppSlug.ValueString(), data.BuildSlug.ValueString())
+	artifacts, err := listBuildArtifacts(ctx, d.client, artifactsPath)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list build artifacts, got error: %s", err))
+		return
+	}
+
+	data.Artifacts = []BuildArtifactsItemModel{}
+	for _, artifact := range artifacts {
+		if !data.ArtifactType.IsNull() && artifact.ArtifactType != data.ArtifactType.ValueString() {
+			continue
+		}
+
+		// Only single artifacts carry their download URLs.
+		respStruct := buildArtifactResponse{}
+		err := d.client.do(ctx, http.MethodGet, artifactsPath+"/"+url.PathEscape(artifact.Slug), nil, nil, &respStruct)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read build artifact %s, got error: %s", artifact.Slug, err))
+			return
+		}
+		artifact = respStruct.Data
+
+		data.Artifacts = append(data.Artifacts, BuildArtifactsItemModel{
+			Slug:                 types.StringValue(artifact.Slug),
+			Title:                types.StringValue(artifact.Title),
+			ArtifactType:         types.StringValue(artifact.ArtifactType),
+			FileSizeBytes:        types.Int64Value(artifact.FileSizeBytes),
+			IsPublicPageEnabled:  types.BoolValue(artifact.IsPublicPageEnabled),
+			DownloadUrl:          types.StringValue(artifact.ExpiringDownloadUrl),
+			PublicInstallPageUrl: types.StringValue(artifact.PublicInstallPageUrl),
+		})
+	}
+	data.Id = types.StringValue(data.BuildSlug.ValueString())
+
+	tflog.Trace(ctx, "read a build artifacts data source", map[string]interface{}{"count": len(data.Artifacts)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func buildArtifactsPath(appSlug, buildSlug string) string {
+	return appBuildsPath(appSlug) + "/" + url.PathEscape(buildSlug) + "/artifacts"
+}
+
+// listBuildArtifacts returns every artifact of a build, following the `next`
+// cursor of the API.
+func listBuildArtifacts(ctx context.Context, client *BitriseClient, artifactsPath string) ([]BuildArtifact, error) {
+	var artifacts []BuildArtifact
+	params := url.Values{}
+	for {
+		respStruct := buildArtifactListResponse{}
+		err := client.do(ctx, http.MethodGet, artifactsPath, params, nil, &respStruct)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, respStruct.Data...)
+		if respStruct.Paging.Next == "" {
+			return artifacts, nil
+		}
+		params.Set("next", respStruct.Paging.Next)
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBuildArtifactsDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	build := fake.AddBuild(app.Slug, Build{Branch: "main", Status: 1, StatusText: "success"})
+	ipa := fake.AddArtifact(build.Slug, BuildArtifact{
+		Title: "PGMobile.ipa", ArtifactType: "ios-ipa", FileSizeBytes: 52428800, IsPublicPageEnabled: true,
+	})
+	fake.AddArtifact(build.Slug, BuildArtifact{Title: "PGMobile.dSYM.zip", ArtifactType: "file", FileSizeBytes: 1024})
+	fake.AddArtifact(build.Slug, BuildArtifact{Title: "test-results.zip", ArtifactType: "file", FileSizeBytes: 2048})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// All artifacts across pages
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_build_artifacts" "test" {
+  app_slug   = %q
+  build_slug = %q
+}
+`, app.Slug, build.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_build_artifacts.test", "id", build.Slug),
+					resource.TestCheckResourceAttr("data.bitrise_build_artifacts.test", "artifacts.#", "3"),
+					resource.TestCheckResourceAttr("data.bitrise_build_artifacts.test", "artifacts.2.title", "test-results.zip"),
+					resource.TestCheckResourceAttr("data.bitrise_build_artifacts.test", "artifacts.2.public_install_page_url", ""),
+				),
+			},
+			// Artifact type filter, with the URLs of the single artifact
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_build_artifacts" "test" {
+  app_slug      = %q
+  build_slug    = %q
+  artifact_type = "ios-ipa"
+}
+`, app.Slug, build.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_build_artifacts.test", "artifacts.#", "1"),
+					resource.TestCheckResourceAttr("data.bitrise_build_artifacts.test", "artifacts.0.slug", ipa.Slug),
+					resource.TestCheckResourceAttr("data.bitrise_build_artifacts.test", "artifacts.0.file_size_bytes", "52428800"),
+					resource.TestCheckResourceAttr("data.bitrise_build_artifacts.test", "artifacts.0.download_url", ipa.ExpiringDownloadUrl),
+					resource.TestCheckResourceAttr("data.bitrise_build_artifacts.test", "artifacts.0.public_install_page_url", ipa.PublicInstallPageUrl),
+				),
+			},
+		},
+	})
+}
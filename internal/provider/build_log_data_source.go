@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BuildLogDataSource{}
+
+func NewBuildLogDataSource() datasource.DataSource {
+	return &BuildLogDataSource{}
+}
+
+// BuildLogDataSource defines the data source implementation.
+type BuildLogDataSource struct {
+	client *BitriseClient
+}
+
+type BuildLog struct {
+	ExpiringRawLogUrl   string          `json:"expiring_raw_log_url"`
+	IsArchived          bool            `json:"is_archived"`
+	LogChunks           []BuildLogChunk `json:"log_chunks"`
+	NextBeforeTimestamp string          `json:"next_before_timestamp"`
+}
+
+type BuildLogChunk struct {
+	Chunk    string `json:"chunk"`
+	Position int64  `json:"position"`
+}
+
+type AppSecret struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	IsProtected bool   `json:"is_protected"`
+}
+
+// BuildLogDataSourceModel describes the data source data model.
+type BuildLogDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	AppSlug       types.String `tfsdk:"app_slug"`
+	BuildSlug     types.String `tfsdk:"build_slug"`
+	TailLines     types.Int64  `tfsdk:"tail_lines"`
+	RedactSecrets types.Bool   `tfsdk:"redact_secrets"`
+	IsArchived    types.Bool   `tfsdk:"is_archived"`
+	Content       types.String `tfsdk:"content"`
+}
+
+func (d *BuildLogDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_build_log"
+}
+
+func (d *BuildLogDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Log of a build. The full log is downloaded once the build is archived, the log chunks available so far are joined while it runs.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the build",
+			},
+			"app_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG of the app",
+			},
+			"build_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG of the build",
+			},
+			"tail_lines": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return the last lines of the log. Defaults to the whole log.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"redact_secrets": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Replace every line containing the value of one of the app secrets, before taking the `tail_lines`. Protected secrets cannot be read back and values shorter than 6 characters are not redacted. Defaults to `false`.",
+			},
+			"is_archived": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the build finished and its log was archived",
+			},
+			"content": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Content of the log",
+			},
+		},
+	}
+}
+
+func (d *BuildLogDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BuildLogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BuildLogDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content, archived, err := readBuildLog(ctx, d.client, data.AppSlug.ValueString(), data.BuildSlug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read build log, got error: %s", err))
+		return
+	}
+
+	// Redact the whole log first, so no multi-line secret is cut in half by
+	// the tail.
+	if data.RedactSecrets.ValueBool() {
+		secrets, err := appSecretValues(ctx, d.client, data.AppSlug.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read app secrets, got error: %s", err))
+			return
+		}
+		content = redactLines(content, secrets)
+	}
+	if !data.TailLines.IsNull() {
+		content = tailLines(content, int(data.TailLines.ValueInt64()))
+	}
+
+	data.Id = types.StringValue(data.BuildSlug.ValueString())
+	data.IsArchived = types.BoolValue(archived)
+	data.Content = types.StringValue(content)
+
+	tflog.Trace(ctx, "read a build log data source", map[string]interface{}{"archived": archived, "bytes": len(content)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readBuildLog returns the log of a build. Archived logs are downloaded from
+// their expiring URL, logs of running builds are joined from their chunks,
+// following `next_before_timestamp` back to the first chunk or until the same
+// cursor comes back.
+func readBuildLog(ctx context.Context, client *BitriseClient, appSlug, buildSlug string) (string, bool, error) {
+	logPath := appBuildsPath(appSlug) + "/" + url.PathEscape(buildSlug) + "/log"
+	chunks := map[int64]string{}
+	cursors := map[string]bool{}
+	params := url.Values{}
+	for {
+		respStruct := BuildLog{}
+		err := client.do(ctx, http.MethodGet, logPath, params, nil, &respStruct)
+		if err != nil {
+			return "", false, err
+		}
+		if respStruct.IsArchived && respStruct.ExpiringRawLogUrl != "" {
+			content, err := downloadRawLog(ctx, client, respStruct.ExpiringRawLogUrl)
+			return content, true, err
+		}
+		for _, chunk := range respStruct.LogChunks {
+			chunks[chunk.Position] = chunk.Chunk
+		}
+		if respStruct.NextBeforeTimestamp == "" || cursors[respStruct.NextBeforeTimestamp] {
+			break
+		}
+		cursors[respStruct.NextBeforeTimestamp] = true
+		params.Set("before_timestamp", respStruct.NextBeforeTimestamp)
+	}
+
+	positions := make([]int64, 0, len(chunks))
+	for position := range chunks {
+		positions = append(positions, position)
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+	var content strings.Builder
+	for _, position := range positions {
+		content.WriteString(chunks[position])
+	}
+	return content.String(), false, nil
+}
+
+// downloadRawLog fetches an archived log from its expiring URL. The URL is
+// presigned, so it is fetched with a plain HTTP client, which neither logs the
+// URL nor sends it through the API transports.
+func downloadRawLog(ctx context.Context, client *BitriseClient, rawLogUrl string) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, rawLogUrl, nil)
+	if err != nil {
+		return "", err
+	}
+	httpClient := &http.Client{Timeout: client.RequestTimeout}
+	res, err := httpClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return "", &APIError{StatusCode: res.StatusCode, Message: "unable to download the archived log"}
+	}
+	return string(body), nil
+}
+
+// tailLines returns the last n lines of content, keeping its trailing
+// newline.
+func tailLines(content string, n int) string {
+	trimmed := strings.TrimSuffix(content, "\n")
+	lines := strings.Split(trimmed, "\n")
+	if len(lines) <= n {
+		return content
+	}
+	return content[len(strings.Join(lines[:len(lines)-n], "\n"))+1:]
+}
+
+// appSecretValues returns the values of the app secrets by name. Protected
+// secrets are left out since the API does not return their values.
+func appSecretValues(ctx context.Context, client *BitriseClient, appSlug string) (map[string]string, error) {
+	secretsPath := "/apps/" + url.PathEscape(appSlug) + "/secrets"
+	var listResp struct {
+		Data []AppSecret `json:"data"`
+	}
+	if err := client.do(ctx, http.MethodGet, secretsPath, nil, nil, &listResp); err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	for _, secret := range listResp.Data {
+		if secret.IsProtected {
+			continue
+		}
+		var secretResp struct {
+			Data AppSecret `json:"data"`
+		}
+		if err := client.do(ctx, http.MethodGet, secretsPath+"/"+url.PathEscape(secret.Name), nil, nil, &secretResp); err != nil {
+			return nil, err
+		}
+		if secretResp.Data.Value != "" {
+			values[secret.Name] = secretResp.Data.Value
+		}
+	}
+	return values, nil
+}
+
+// minRedactedSecretLength is the length below which secret values are not
+// redacted, since values such as `1` or `true` would redact most lines.
+const minRedactedSecretLength = 6
+
+// redactLines replaces every line containing one of the secret values with a
+// note naming the secret, so the value never reaches the state. Values are
+// first marked across the whole content, so multi-line values such as keys
+// and certificates are found too, and their lines are replaced by a single
+// note.
+func redactLines(content string, secrets map[string]string) string {
+	names := make([]string, 0, len(secrets))
+	for name, value := range secrets {
+		if len(value) >= minRedactedSecretLength {
+			names = append(names, name)
+		}
+	}
+	// Longer values first, so a value which is part of another one does not
+	// break its match.
+	sort.Slice(names, func(i, j int) bool {
+		if len(secrets[names[i]]) != len(secrets[names[j]]) {
+			return len(secrets[names[i]]) > len(secrets[names[j]])
+		}
+		return names[i] < names[j]
+	})
+
+	marker := func(name string) string { return "\x00" + name + "\x00" }
+	for _, name := range names {
+		content = strings.ReplaceAll(content, secrets[name], marker(name))
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if !strings.Contains(line, "\x00") {
+			continue
+		}
+		for _, name := range names {
+			if strings.Contains(line, marker(name)) {
+				lines[i] = fmt.Sprintf("%s line redacted, it contains the value of %s %s", redacted, name, redacted)
+				break
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestTailLines(t *testing.T) {
+	for _, test := range []struct {
+		content string
+		n       int
+		want    string
+	}{
+		{"a\nb\nc\n", 2, "b\nc\n"},
+		{"a\nb\nc", 2, "b\nc"},
+		{"a\nb\nc\n", 3, "a\nb\nc\n"},
+		{"a\nb\nc\n", 10, "a\nb\nc\n"},
+		{"", 1, ""},
+	} {
+		if got := tailLines(test.content, test.n); got != test.want {
+			t.Errorf("tailLines(%q, %d) = %q, want %q", test.content, test.n, got, test.want)
+		}
+	}
+}
+
+func TestRedactLines(t *testing.T) {
+	testCases := map[string]struct {
+		content string
+		secrets map[string]string
+		want    string
+	}{
+		"single line": {
+			content: "cloning\nexport API_KEY=s3cr3t\nusing key s3cr3t and pa55w0rd\ndone\n",
+			secrets: map[string]string{"API_KEY": "s3cr3t", "PASSWORD": "pa55w0rd"},
+			want: "cloning\n" +
+				"*** line redacted, it contains the value of API_KEY ***\n" +
+				"*** line redacted, it contains the value of PASSWORD ***\n" +
+				"done\n",
+		},
+		"multi-line": {
+			content: "cloning\nkey: -----BEGIN KEY-----\nMIIEpAIB\n-----END KEY----- written\ndone\n",
+			secrets: map[string]string{"SSH_KEY": "-----BEGIN KEY-----\nMIIEpAIB\n-----END KEY-----"},
+			want: "cloning\n" +
+				"*** line redacted, it contains the value of SSH_KEY ***\n" +
+				"done\n",
+		},
+		"short": {
+			content: "step 1 of 2\ncache: true\n",
+			secrets: map[string]string{"RETRIES": "1", "USE_CACHE": "true"},
+			want:    "step 1 of 2\ncache: true\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := redactLines(testCase.content, testCase.secrets); got != testCase.want {
+				t.Errorf("expected %q, got %q", testCase.want, got)
+			}
+		})
+	}
+}
+
+func TestReadBuildLogRepeatedCursor(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 10 {
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		log := BuildLog{NextBeforeTimestamp: "100"}
+		if r.URL.Query().Get("before_timestamp") == "" {
+			log.LogChunks = []BuildLogChunk{{Chunk: "done\n", Position: 1}}
+		} else {
+			log.LogChunks = []BuildLogChunk{{Chunk: "cloning\n", Position: 0}}
+		}
+		_ = json.NewEncoder(w).Encode(log)
+	}))
+	defer server.Close()
+
+	client := &BitriseClient{HTTPClient: server.Client(), Endpoint: server.URL, Token: "token"}
+
+	content, archived, err := readBuildLog(context.Background(), client, "a1", "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if archived || content != "cloning\ndone\n" {
+		t.Errorf("expected the log of both chunks, got %q (archived %t)", content, archived)
+	}
+	if requests != 2 {
+		t.Errorf("expected to stop once the cursor repeats, got %d requests", requests)
+	}
+}
+
+func TestAccBuildLogDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	failed := fake.AddBuild(app.Slug, Build{Branch: "main", Status: 2, StatusText: "error"})
+	fake.SetBuildLog(failed.Slug, []string{
+		"Cloning repository\n",
+		"Signing in with token s3cr3t\n",
+		"Running tests\n",
+		"Tests failed\n",
+	}, true)
+	running := fake.AddBuild(app.Slug, Build{Branch: "main", Status: 0, StatusText: "in-progress"})
+	fake.SetBuildLog(running.Slug, []string{"one\n", "two\n", "three\n", "four\n", "five\n"}, false)
+	fake.AddSecretValue(app.Slug, "DEPLOY_TOKEN", "s3cr3t", false)
+	fake.AddSecretValue(app.Slug, "SIGNING_PASSWORD", "Running", true)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Archived log, redacted
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_build_log" "test" {
+  app_slug       = %q
+  build_slug     = %q
+  redact_secrets = true
+}
+`, app.Slug, failed.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_build_log.test", "id", failed.Slug),
+					resource.TestCheckResourceAttr("data.bitrise_build_log.test", "is_archived", "true"),
+					resource.TestCheckResourceAttr("data.bitrise_build_log.test", "content",
+						"Cloning repository\n*** line redacted, it contains the value of DEPLOY_TOKEN ***\nRunning tests\nTests failed\n"),
+				),
+			},
+			// Archived log, tailed
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_build_log" "test" {
+  app_slug   = %q
+  build_slug = %q
+  tail_lines = 1
+}
+`, app.Slug, failed.Slug),
+				Check: resource.TestCheckResourceAttr("data.bitrise_build_log.test", "content", "Tests failed\n"),
+			},
+			// Chunks of a running build across pages
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_build_log" "test" {
+  app_slug   = %q
+  build_slug = %q
+}
+`, app.Slug, running.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_build_log.test", "is_archived", "false"),
+					resource.TestCheckResourceAttr("data.bitrise_build_log.test", "content", "one\ntwo\nthree\nfour\nfive\n"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,390 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultBuildsLimit is the number of builds returned when no `limit` is
+	// set, so apps with a long history are not listed whole.
+	defaultBuildsLimit = 50
+	// maxBuildsPageSize is the largest page the builds API returns.
+	maxBuildsPageSize = 50
+)
+
+// buildStatuses maps the status texts of the API to its numeric status
+// filter.
+var buildStatuses = map[string]int64{
+	"in-progress":          0,
+	"success":              1,
+	"error":                2,
+	"aborted":              3,
+	"aborted-with-success": 4,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BuildsDataSource{}
+
+func NewBuildsDataSource() datasource.DataSource {
+	return &BuildsDataSource{}
+}
+
+// BuildsDataSource defines the data source implementation.
+type BuildsDataSource struct {
+	client *BitriseClient
+}
+
+type Build struct {
+	Slug              string `json:"slug"`
+	BuildNumber       int64  `json:"build_number"`
+	Status            int64  `json:"status"`
+	StatusText        string `json:"status_text"`
+	AbortReason       string `json:"abort_reason"`
+	Branch            string `json:"branch"`
+	CommitHash        string `json:"commit_hash"`
+	CommitMessage     string `json:"commit_message"`
+	Tag               string `json:"tag"`
+	TriggeredWorkflow string `json:"triggered_workflow"`
+	TriggeredBy       string `json:"triggered_by"`
+	TriggeredAt       string `json:"triggered_at"`
+	StartedOnWorkerAt string `json:"started_on_worker_at"`
+	FinishedAt        string `json:"finished_at"`
+	PullRequestId     int64  `json:"pull_request_id"`
+	PullRequestTarget string `json:"pull_request_target_branch"`
+	StackIdentifier   string `json:"stack_identifier"`
+	MachineTypeId     string `json:"machine_type_id"`
+}
+
+type buildListResponse struct {
+	Data   []Build `json:"data"`
+	Paging Paging  `json:"paging"`
+}
+
+// BuildsDataSourceModel describes the data source data model.
+type BuildsDataSourceModel struct {
+	Id              types.String      `tfsdk:"id"`
+	AppSlug         types.String      `tfsdk:"app_slug"`
+	Branch          types.String      `tfsdk:"branch"`
+	Workflow        types.String      `tfsdk:"workflow"`
+	Status          types.String      `tfsdk:"status"`
+	TriggeredAfter  types.String      `tfsdk:"triggered_after"`
+	TriggeredBefore types.String      `tfsdk:"triggered_before"`
+	PullRequestId   types.Int64       `tfsdk:"pull_request_id"`
+	Limit           types.Int64       `tfsdk:"limit"`
+	Builds          []BuildsItemModel `tfsdk:"builds"`
+}
+
+// BuildsItemModel describes a single build of the builds data source.
+type BuildsItemModel struct {
+	Slug              types.String `tfsdk:"slug"`
+	BuildNumber       types.Int64  `tfsdk:"build_number"`
+	Status            types.String `tfsdk:"status"`
+	Branch            types.String `tfsdk:"branch"`
+	CommitHash        types.String `tfsdk:"commit_hash"`
+	CommitMessage     types.String `tfsdk:"commit_message"`
+	TriggeredWorkflow types.String `tfsdk:"triggered_workflow"`
+	TriggeredBy       types.String `tfsdk:"triggered_by"`
+	TriggeredAt       types.String `tfsdk:"triggered_at"`
+	StartedAt         types.String `tfsdk:"started_at"`
+	FinishedAt        types.String `tfsdk:"finished_at"`
+	DurationSeconds   types.Int64  `tfsdk:"duration_seconds"`
+	PullRequestId     types.Int64  `tfsdk:"pull_request_id"`
+}
+
+func (d *BuildsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_builds"
+}
+
+func (d *BuildsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the builds of an app, most recent first, following pagination up to `limit` builds.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the app",
+			},
+			"app_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG of the app",
+			},
+			"branch": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return builds of this branch",
+			},
+			"workflow": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return builds of this workflow",
+			},
+			"status": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return builds with this status: `in-progress`, `success`, `error`, `aborted` or `aborted-with-success`",
+				Validators: []validator.String{
+					stringvalidator.OneOf("in-progress", "success", "error", "aborted", "aborted-with-success"),
+				},
+			},
+			"triggered_after": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return builds triggered after this RFC 3339 timestamp",
+			},
+			"triggered_before": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return builds triggered before this RFC 3339 timestamp",
+			},
+			"pull_request_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return builds of this pull request",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum number of builds to return. Defaults to `%d`.", defaultBuildsLimit),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"builds": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Builds matching the filters, most recent first",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: buildAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// buildAttributes are the attributes describing a single build.
+func buildAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"slug": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "SLUG of the build",
+		},
+		"build_number": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Number of the build",
+		},
+		"status": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Status of the build: `in-progress`, `success`, `error`, `aborted` or `aborted-with-success`",
+		},
+		"branch": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Branch of the build",
+		},
+		"commit_hash": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Commit hash of the build",
+		},
+		"commit_message": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Commit message of the build",
+		},
+		"triggered_workflow": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Workflow run by the build",
+		},
+		"triggered_by": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "What triggered the build, e.g. a webhook or a user",
+		},
+		"triggered_at": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "When the build was triggered",
+		},
+		"started_at": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "When the build started on a worker, empty while it is on hold",
+		},
+		"finished_at": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "When the build finished, empty while it is running",
+		},
+		"duration_seconds": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Time between the start and the end of the build, null until it finished",
+		},
+		"pull_request_id": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Pull request of the build, `0` when it was not triggered by one",
+		},
+	}
+}
+
+func (d *BuildsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BuildsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BuildsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{}
+	if !data.Branch.IsNull() {
+		query.Set("branch", data.Branch.ValueString())
+	}
+	if !data.Workflow.IsNull() {
+		query.Set("workflow", data.Workflow.ValueString())
+	}
+	if !data.Status.IsNull() {
+		query.Set("status", strconv.FormatInt(buildStatuses[data.Status.ValueString()], 10))
+	}
+	if !data.PullRequestId.IsNull() {
+		query.Set("pull_request_id", strconv.FormatInt(data.PullRequestId.ValueInt64(), 10))
+	}
+	setTimestampParam(query, "after", data.TriggeredAfter, path.Root("triggered_after"), resp)
+	setTimestampParam(query, "before", data.TriggeredBefore, path.Root("triggered_before"), resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultBuildsLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+
+	builds, err := listBuilds(ctx, d.client, data.AppSlug.ValueString(), query, limit)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list builds, got error: %s", err))
+		return
+	}
+
+	data.Builds = []BuildsItemModel{}
+	for _, build := range builds {
+		data.Builds = append(data.Builds, newBuildsItemModel(build))
+	}
+	data.Id = types.StringValue(data.AppSlug.ValueString())
+
+	tflog.Trace(ctx, "read a builds data source", map[string]interface{}{"count": len(data.Builds)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setTimestampParam converts an RFC 3339 attribute to the UNIX timestamp
+// expected by the builds API.
+func setTimestampParam(query url.Values, param string, value types.String, attribute path.Path, resp *datasource.ReadResponse) {
+	if value.IsNull() {
+		return
+	}
+	timestamp, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			attribute,
+			"Invalid Timestamp",
+			fmt.Sprintf("Expected an RFC 3339 timestamp such as `2023-04-01T00:00:00Z`, got: %q", value.ValueString()),
+		)
+		return
+	}
+	query.Set(param, strconv.FormatInt(timestamp.Unix(), 10))
+}
+
+func appBuildsPath(appSlug string) string {
+	return "/apps/" + url.PathEscape(appSlug) + "/builds"
+}
+
+// listBuilds returns up to limit builds of the app, most recent first,
+// following the `next` cursor of the API.
+func listBuilds(ctx context.Context, client *BitriseClient, appSlug string, query url.Values, limit int) ([]Build, error) {
+	var builds []Build
+	params := url.Values{}
+	for k, v := range query {
+		params[k] = v
+	}
+	params.Set("sort_by", "created_at")
+	for len(builds) < limit {
+		pageSize := limit - len(builds)
+		if pageSize > maxBuildsPageSize {
+			pageSize = maxBuildsPageSize
+		}
+		params.Set("limit", strconv.Itoa(pageSize))
+
+		respStruct := buildListResponse{}
+		err := client.do(ctx, http.MethodGet, appBuildsPath(appSlug), params, nil, &respStruct)
+		if err != nil {
+			return nil, err
+		}
+		builds = append(builds, respStruct.Data...)
+		if respStruct.Paging.Next == "" {
+			break
+		}
+		params.Set("next", respStruct.Paging.Next)
+	}
+	if len(builds) > limit {
+		builds = builds[:limit]
+	}
+	return builds, nil
+}
+
+func newBuildsItemModel(build Build) BuildsItemModel {
+	item := BuildsItemModel{
+		Slug:              types.StringValue(build.Slug),
+		BuildNumber:       types.Int64Value(build.BuildNumber),
+		Status:            types.StringValue(build.StatusText),
+		Branch:            types.StringValue(build.Branch),
+		CommitHash:        types.StringValue(build.CommitHash),
+		CommitMessage:     types.StringValue(build.CommitMessage),
+		TriggeredWorkflow: types.StringValue(build.TriggeredWorkflow),
+		TriggeredBy:       types.StringValue(build.TriggeredBy),
+		TriggeredAt:       types.StringValue(build.TriggeredAt),
+		StartedAt:         types.StringValue(build.StartedOnWorkerAt),
+		FinishedAt:        types.StringValue(build.FinishedAt),
+		DurationSeconds:   types.Int64Null(),
+		PullRequestId:     types.Int64Value(build.PullRequestId),
+	}
+	if duration, ok := buildDuration(build); ok {
+		item.DurationSeconds = types.Int64Value(int64(duration.Seconds()))
+	}
+	return item
+}
+
+// buildDuration is the time the build spent on a worker, known once it
+// finished.
+func buildDuration(build Build) (time.Duration, bool) {
+	started, err := time.Parse(time.RFC3339, build.StartedOnWorkerAt)
+	if err != nil {
+		return 0, false
+	}
+	finished, err := time.Parse(time.RFC3339, build.FinishedAt)
+	if err != nil {
+		return 0, false
+	}
+	return finished.Sub(started), true
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestListBuildsLimit(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	for i := 0; i < 5; i++ {
+		fake.AddBuild(app.Slug, Build{Branch: "main"})
+	}
+	client := &BitriseClient{HTTPClient: fake.server.Client(), Endpoint: fake.server.URL, Token: fakeBitriseToken}
+
+	for limit, want := range map[int]int{1: 1, 3: 3, 5: 5, 10: 5} {
+		builds, err := listBuilds(context.Background(), client, app.Slug, url.Values{}, limit)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(builds) != want {
+			t.Errorf("expected %d builds with limit %d, got %d", want, limit, len(builds))
+		}
+	}
+}
+
+func TestAccBuildsDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	fake.AddBuild(app.Slug, Build{
+		Branch: "main", TriggeredWorkflow: "release", Status: 1, StatusText: "success",
+		TriggeredAt: "2023-04-01T10:00:00Z", StartedOnWorkerAt: "2023-04-01T10:00:30Z", FinishedAt: "2023-04-01T10:12:30Z",
+	})
+	fake.AddBuild(app.Slug, Build{
+		Branch: "feature", TriggeredWorkflow: "primary", Status: 2, StatusText: "error", PullRequestId: 42,
+		TriggeredAt: "2023-04-02T10:00:00Z",
+	})
+	fake.AddBuild(app.Slug, Build{
+		Branch: "main", TriggeredWorkflow: "release", Status: 2, StatusText: "error",
+		TriggeredAt: "2023-04-03T10:00:00Z",
+	})
+	latest := fake.AddBuild(app.Slug, Build{
+		Branch: "main", TriggeredWorkflow: "release", Status: 1, StatusText: "success", CommitHash: "0123abc",
+		TriggeredAt: "2023-04-04T10:00:00Z", StartedOnWorkerAt: "2023-04-04T10:01:00Z", FinishedAt: "2023-04-04T10:11:00Z",
+	})
+	fake.AddBuild(app.Slug, Build{
+		Branch: "main", TriggeredWorkflow: "release", Status: 0, StatusText: "in-progress",
+		TriggeredAt: "2023-04-05T10:00:00Z", StartedOnWorkerAt: "2023-04-05T10:01:00Z",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Status and branch filters across pages
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_builds" "test" {
+  app_slug = %q
+  branch   = "main"
+  status   = "success"
+}
+`, app.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_builds.test", "builds.#", "2"),
+					resource.TestCheckResourceAttr("data.bitrise_builds.test", "builds.0.slug", latest.Slug),
+					resource.TestCheckResourceAttr("data.bitrise_builds.test", "builds.0.commit_hash", "0123abc"),
+					resource.TestCheckResourceAttr("data.bitrise_builds.test", "builds.0.duration_seconds", "600"),
+					resource.TestCheckResourceAttr("data.bitrise_builds.test", "builds.1.duration_seconds", "720"),
+				),
+			},
+			// Limit, time range and running builds
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_builds" "test" {
+  app_slug        = %q
+  triggered_after = "2023-04-01T12:00:00Z"
+  limit           = 3
+}
+`, app.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_builds.test", "builds.#", "3"),
+					resource.TestCheckResourceAttr("data.bitrise_builds.test", "builds.0.status", "in-progress"),
+					resource.TestCheckNoResourceAttr("data.bitrise_builds.test", "builds.0.duration_seconds"),
+				),
+			},
+			// Pull request filter
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_builds" "test" {
+  app_slug        = %q
+  pull_request_id = 42
+}
+`, app.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_builds.test", "builds.#", "1"),
+					resource.TestCheckResourceAttr("data.bitrise_builds.test", "builds.0.branch", "feature"),
+				),
+			},
+		},
+	})
+}
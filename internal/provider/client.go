@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultEndpoint is the base URL of the public Bitrise API.
+const defaultEndpoint = "https://api.bitrise.io/v0.1"
+
+// BitriseClient is the API client handed to resources and data sources by
+// the provider.
+type BitriseClient struct {
+	HTTPClient *http.Client
+	Endpoint   string
+	Token      string
+	// RequestTimeout bounds each attempt of an API call, as enforced by the
+	// transports of newAPITransport, and each transfer to or from a presigned
+	// URL. Zero means no limit besides the context of the operation.
+	RequestTimeout time.Duration
+}
+
+// newAPITransport stacks the transports every API request goes through:
+// retries, the rate limit shared by all resources, a timeout for each attempt
+// and logging, in that order.
+func newAPITransport(base http.RoundTripper, maxRetries int, retryMaxWait, requestTimeout time.Duration) http.RoundTripper {
+	return newRetryTransport(
+		newRateLimitTransport(newTimeoutTransport(newLoggingTransport(base), requestTimeout)),
+		maxRetries, retryMaxWait,
+	)
+}
+
+// APIError is returned when the Bitrise API answers with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("bitrise API returned status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("bitrise API returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is an API error with a 404 status.
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Paging is the cursor based paging block of Bitrise list responses.
+type Paging struct {
+	TotalItemCount int    `json:"total_item_count"`
+	PageItemLimit  int    `json:"page_item_limit"`
+	Next           string `json:"next"`
+}
+
+// withToken returns a copy of the client that authenticates with token, or
+// the client itself when token is empty.
+func (c *BitriseClient) withToken(token string) *BitriseClient {
+	if token == "" {
+		return c
+	}
+	clone := *c
+	clone.Token = token
+	return &clone
+}
+
+// do sends a request to the Bitrise API. in is marshalled as the JSON body
+// when non-nil and the response body is decoded into out when non-nil.
+func (c *BitriseClient) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	if c.Token == "" {
+		return fmt.Errorf("no Bitrise access token configured")
+	}
+
+	endpoint := strings.TrimSuffix(c.Endpoint, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var body *bytes.Reader
+	if in != nil {
+		marshalled, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(marshalled)
+	} else {
+		body = bytes.NewReader(nil)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", c.Token)
+	request.Header.Set("Accept", "application/json")
+	if in != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	respBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return newAPIError(res.StatusCode, respBody)
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+func newAPIError(status int, body []byte) *APIError {
+	var errResp struct {
+		Message  string `json:"message"`
+		ErrorMsg string `json:"error_msg"`
+	}
+	apiErr := &APIError{StatusCode: status}
+	if json.Unmarshal(body, &errResp) == nil {
+		apiErr.Message = errResp.Message
+		if apiErr.Message == "" {
+			apiErr.Message = errResp.ErrorMsg
+		}
+	}
+	return apiErr
+}
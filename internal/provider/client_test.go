@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientRequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &BitriseClient{
+		HTTPClient: &http.Client{Transport: newAPITransport(http.DefaultTransport, 0, time.Second, 50*time.Millisecond)},
+		Endpoint:   server.URL,
+		Token:      "token",
+	}
+	err := client.do(context.Background(), http.MethodGet, "/me", nil, nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to time out, got: %v", err)
+	}
+
+	client.HTTPClient = &http.Client{Transport: newAPITransport(http.DefaultTransport, 0, time.Second, 0)}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	err = client.do(ctx, http.MethodGet, "/me", nil, nil, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the request to be cancelled, got: %v", err)
+	}
+}
+
+func TestClientAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}))
+	defer server.Close()
+
+	client := &BitriseClient{HTTPClient: server.Client(), Endpoint: server.URL, Token: "token"}
+	err := client.do(context.Background(), http.MethodGet, "/apps/missing", nil, nil, nil)
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got: %v", err)
+	}
+	if err.Error() != "bitrise API returned status 404: Not Found" {
+		t.Errorf("unexpected error message: %s", err)
+	}
+}
@@ -0,0 +1,1553 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// fakeBitriseToken is the only token accepted by the fake API.
+	fakeBitriseToken = "fake-bitrise-token"
+
+	// Organizations seeded in the fake API. The token owner has no rights in
+	// fakeRestrictedOrgSlug.
+	fakeOrgSlug           = "cf38e3d194d03fa2"
+	fakeOtherOrgSlug      = "0a1b2c3d4e5f6a7b"
+	fakeRestrictedOrgSlug = "ffffffffffffffff"
+)
+
+// fakeBitrise is an in-process, stateful stand-in for the Bitrise API used by
+// the acceptance tests, so they run without a Bitrise account.
+type fakeBitrise struct {
+	t      *testing.T
+	server *httptest.Server
+	routes []fakeRoute
+
+	mutex         sync.Mutex
+	nextID        int
+	pageSize      int
+	failures      map[string][]int
+	user          User
+	organizations []*Organization
+	restricted    map[string]bool
+	apps          []*App
+	appRoles      map[string][]string
+	triggerTokens map[string]string
+	addons        []Addon
+	appAddons     map[string]map[string]*AppAddon
+	devices       map[string][]*TestDevice
+	secrets       map[string]map[string]*fakeSecret
+	webhooks      map[string]map[string]*fakeWebhook
+	files         map[string]map[string]*fakeFile
+	avatars       map[string]map[string]*fakeAvatarCandidate
+	avatarContent map[string][]byte
+	builds        map[string][]*Build
+	artifacts     map[string][]*BuildArtifact
+	logs          map[string]*fakeBuildLog
+	members       map[string][]*OrganizationMember
+	groups        map[string][]*OrganizationGroup
+	groupMembers  map[string]map[string]bool
+}
+
+type fakeSecret struct {
+	Name                    string `json:"name"`
+	Value                   string `json:"value"`
+	IsProtected             bool   `json:"is_protected"`
+	IsExposedForPullRequest bool   `json:"expose_for_pull_requests"`
+}
+
+type fakeWebhook struct {
+	Slug    string            `json:"slug"`
+	Url     string            `json:"url"`
+	Events  []string          `json:"events"`
+	Headers map[string]string `json:"headers"`
+}
+
+type fakeFile struct {
+	Slug           string `json:"slug"`
+	UploadFileName string `json:"upload_file_name"`
+	UploadFileSize int64  `json:"upload_file_size"`
+	UserEnvKey     string `json:"user_env_key"`
+	UploadUrl      string `json:"upload_url,omitempty"`
+	IsUploaded     bool   `json:"is_uploaded"`
+	content        []byte
+}
+
+type fakeAvatarCandidate struct {
+	AvatarCandidate
+	content []byte
+}
+
+type fakeBuildLog struct {
+	chunks   []string
+	archived bool
+}
+
+type fakeRoute struct {
+	pattern  string
+	method   string
+	segments []string
+	public   bool
+	handler  func(w http.ResponseWriter, r *http.Request, params map[string]string)
+}
+
+// newFakeBitrise starts a fake Bitrise API that is shut down with the test.
+func newFakeBitrise(t *testing.T) *fakeBitrise {
+	f := &fakeBitrise{
+		t:        t,
+		failures: map[string][]int{},
+		pageSize: 2,
+		user:     User{Slug: "u0000000000000001", Username: "nate", Email: "nate@example.com"},
+		organizations: []*Organization{
+			{Slug: fakeOrgSlug, Name: "PG Mobile", Plan: "teams", ConcurrencyCount: 4},
+			{Slug: fakeOtherOrgSlug, Name: "PG Web", Plan: "teams", ConcurrencyCount: 2},
+		},
+		restricted:    map[string]bool{fakeRestrictedOrgSlug: true},
+		appRoles:      map[string][]string{},
+		triggerTokens: map[string]string{},
+		addons: []Addon{
+			{
+				Id: "addons-ship", Title: "Ship", Summary: "Distribute builds to testers",
+				Plans: []AddonPlan{
+					{Id: "free", Name: "Free", Features: []string{"5 testers"}},
+					{Id: "pro", Name: "Pro", Price: 4900, Features: []string{"Unlimited testers", "Release notes"}},
+				},
+			},
+			{
+				Id: "addons-insights", Title: "Insights", Summary: "Build and test analytics", IsBeta: true,
+				Plans: []AddonPlan{{Id: "standard", Name: "Standard", Price: 2900}},
+			},
+		},
+		appAddons:     map[string]map[string]*AppAddon{},
+		devices:       map[string][]*TestDevice{},
+		secrets:       map[string]map[string]*fakeSecret{},
+		webhooks:      map[string]map[string]*fakeWebhook{},
+		files:         map[string]map[string]*fakeFile{},
+		avatars:       map[string]map[string]*fakeAvatarCandidate{},
+		avatarContent: map[string][]byte{},
+		builds:        map[string][]*Build{},
+		artifacts:     map[string][]*BuildArtifact{},
+		logs:          map[string]*fakeBuildLog{},
+		members:       map[string][]*OrganizationMember{},
+		groups:        map[string][]*OrganizationGroup{},
+		groupMembers:  map[string]map[string]bool{},
+	}
+	for _, org := range f.organizations {
+		org.Owners = []OrganizationOwner{{Slug: f.user.Slug, Username: f.user.Username, Email: f.user.Email}}
+		org.MembersCount = 1
+		f.members[org.Slug] = []*OrganizationMember{
+			{Slug: f.user.Slug, Email: f.user.Email, Username: f.user.Username, Role: "owner", Status: "active"},
+		}
+	}
+
+	f.route("GET /me", f.getMe)
+	f.route("GET /me/test-devices", f.listDevices)
+	f.route("POST /me/test-devices", f.registerDevice)
+	f.route("GET /me/test-devices/{device}", f.getDevice)
+	f.route("PATCH /me/test-devices/{device}", f.updateDevice)
+	f.route("DELETE /me/test-devices/{device}", f.deleteDevice)
+	f.route("GET /organizations", f.listOrganizations)
+	f.route("GET /organizations/{org}", f.getOrganization)
+	f.route("GET /organizations/{org}/apps", f.listApps)
+	f.route("GET /organizations/{org}/members", f.listMembers)
+	f.route("POST /organizations/{org}/members", f.inviteMember)
+	f.route("PATCH /organizations/{org}/members/{member}", f.updateMember)
+	f.route("DELETE /organizations/{org}/members/{member}", f.removeMember)
+	f.route("GET /organizations/{org}/test-devices", f.listDevices)
+	f.route("POST /organizations/{org}/test-devices", f.registerDevice)
+	f.route("GET /organizations/{org}/test-devices/{device}", f.getDevice)
+	f.route("PATCH /organizations/{org}/test-devices/{device}", f.updateDevice)
+	f.route("DELETE /organizations/{org}/test-devices/{device}", f.deleteDevice)
+	f.route("POST /organizations/{org}/groups", f.createGroup)
+	f.route("GET /organizations/{org}/groups/{group}", f.getGroup)
+	f.route("PATCH /organizations/{org}/groups/{group}", f.updateGroup)
+	f.route("DELETE /organizations/{org}/groups/{group}", f.deleteGroup)
+	f.route("GET /organizations/{org}/groups/{group}/members", f.listGroupMembers)
+	f.route("PUT /organizations/{org}/groups/{group}/members/{user}", f.addGroupMember)
+	f.route("DELETE /organizations/{org}/groups/{group}/members/{user}", f.removeGroupMember)
+	f.route("GET /addons", f.listAddons)
+	f.route("GET /apps", f.listApps)
+	f.route("POST /apps/register", f.registerApp)
+	f.route("POST /apps/{app}/finish", f.finishApp)
+	f.route("GET /apps/{app}", f.getApp)
+	f.route("PATCH /apps/{app}", f.updateApp)
+	f.route("DELETE /apps/{app}", f.deleteApp)
+	f.route("POST /apps/{app}/transfer", f.transferApp)
+	f.route("GET /apps/{app}/build-trigger-token", f.getTriggerToken)
+	f.route("GET /apps/{app}/addons", f.listAppAddons)
+	f.route("PUT /apps/{app}/addons/{addon}", f.putAppAddon)
+	f.route("DELETE /apps/{app}/addons/{addon}", f.deleteAppAddon)
+	f.route("PATCH /apps/{app}/build-trigger-token", f.regenerateTriggerToken)
+	f.route("GET /apps/{app}/builds", f.listBuilds)
+	f.route("GET /apps/{app}/builds/{build}/artifacts", f.listArtifacts)
+	f.route("GET /apps/{app}/builds/{build}/artifacts/{artifact}", f.getArtifact)
+	f.route("GET /apps/{app}/builds/{build}/log", f.getBuildLog)
+	f.route("POST /apps/{app}/builds/{build}/abort", f.abortBuild)
+	f.publicRoute("GET /logs/{build}", f.downloadBuildLog)
+	f.route("GET /apps/{app}/roles/{role}", f.getAppRole)
+	f.route("PUT /apps/{app}/roles/{role}", f.putAppRole)
+	f.route("GET /apps/{app}/secrets", f.listSecrets)
+	f.route("GET /apps/{app}/secrets/{name}", f.getSecret)
+	f.route("PUT /apps/{app}/secrets/{name}", f.putSecret)
+	f.route("DELETE /apps/{app}/secrets/{name}", f.deleteSecret)
+	f.route("GET /apps/{app}/outgoing-webhooks", f.listWebhooks)
+	f.route("POST /apps/{app}/outgoing-webhooks", f.createWebhook)
+	f.route("PUT /apps/{app}/outgoing-webhooks/{webhook}", f.updateWebhook)
+	f.route("DELETE /apps/{app}/outgoing-webhooks/{webhook}", f.deleteWebhook)
+	f.route("GET /apps/{app}/generic-project-files", f.listFiles)
+	f.route("POST /apps/{app}/generic-project-files", f.createFile)
+	f.route("GET /apps/{app}/generic-project-files/{file}", f.getFile)
+	f.route("POST /apps/{app}/generic-project-files/{file}/uploaded", f.confirmFile)
+	f.route("DELETE /apps/{app}/generic-project-files/{file}", f.deleteFile)
+	f.publicRoute("PUT /uploads/{app}/{file}", f.uploadFile)
+	f.route("POST /apps/{app}/avatar-candidates", f.createAvatarCandidates)
+	f.route("PATCH /apps/{app}/avatar-candidates/{candidate}", f.promoteAvatarCandidate)
+	f.publicRoute("PUT /avatar-uploads/{app}/{candidate}", f.uploadAvatarCandidate)
+
+	f.server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// providerConfig returns a provider block pointing at the fake API.
+func (f *fakeBitrise) providerConfig() string {
+	return fmt.Sprintf(`
+provider "bitrise" {
+  endpoint = %q
+  token    = %q
+}
+`, f.server.URL, fakeBitriseToken)
+}
+
+func (f *fakeBitrise) route(pattern string, handler func(w http.ResponseWriter, r *http.Request, params map[string]string)) {
+	method, path, _ := strings.Cut(pattern, " ")
+	f.routes = append(f.routes, fakeRoute{pattern: pattern, method: method, segments: strings.Split(strings.Trim(path, "/"), "/"), handler: handler})
+}
+
+// publicRoute registers a route that needs no token, such as presigned
+// upload URLs.
+func (f *fakeBitrise) publicRoute(pattern string, handler func(w http.ResponseWriter, r *http.Request, params map[string]string)) {
+	f.route(pattern, handler)
+	f.routes[len(f.routes)-1].public = true
+}
+
+func (f *fakeBitrise) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	for _, route := range f.routes {
+		params, ok := route.match(r.Method, segments)
+		if !ok {
+			continue
+		}
+		if !route.public && r.Header.Get("Authorization") != fakeBitriseToken {
+			writeFakeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		if queued := f.failures[route.pattern]; len(queued) > 0 {
+			status := queued[0]
+			f.failures[route.pattern] = queued[1:]
+			if status != 0 {
+				writeFakeError(w, status, http.StatusText(status))
+				return
+			}
+		}
+		route.handler(w, r, params)
+		return
+	}
+	writeFakeError(w, http.StatusNotFound, "Not Found")
+}
+
+// FailNext makes the next request matching the route pattern, such as
+// `POST /apps/{app}/finish`, fail with status.
+func (f *fakeBitrise) FailNext(pattern string, status int) {
+	f.FailAfter(pattern, 0, status)
+}
+
+// FailAfter serves n more requests matching the route pattern, then makes the
+// following one fail with status.
+func (f *fakeBitrise) FailAfter(pattern string, n int, status int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.failures[pattern] = append(make([]int, n), status)
+}
+
+func (r fakeRoute) match(method string, segments []string) (map[string]string, bool) {
+	if method != r.method || len(segments) != len(r.segments) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			params[strings.Trim(segment, "{}")] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (f *fakeBitrise) newSlug() string {
+	f.nextID++
+	return fmt.Sprintf("%016x", 0xa000000000000000+uint64(f.nextID))
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeFakeError(w http.ResponseWriter, status int, message string) {
+	writeFakeJSON(w, status, map[string]string{"message": message})
+}
+
+func decodeFake(w http.ResponseWriter, r *http.Request, into interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(into); err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+// Lookups, callers hold the mutex.
+
+func (f *fakeBitrise) organization(slug string) *Organization {
+	for _, org := range f.organizations {
+		if org.Slug == slug {
+			return org
+		}
+	}
+	return nil
+}
+
+func (f *fakeBitrise) app(slug string) *App {
+	for _, app := range f.apps {
+		if app.Slug == slug {
+			return app
+		}
+	}
+	return nil
+}
+
+// App returns a copy of the app with the given slug, or nil.
+func (f *fakeBitrise) App(slug string) *App {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	app := f.app(slug)
+	if app == nil {
+		return nil
+	}
+	clone := *app
+	return &clone
+}
+
+// AppCount returns the number of registered apps.
+func (f *fakeBitrise) AppCount() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return len(f.apps)
+}
+
+// AppRoleGroups returns the groups having role on the app.
+func (f *fakeBitrise) AppRoleGroups(appSlug, role string) []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]string{}, f.appRoles[appSlug+"/"+role]...)
+}
+
+// AddApp registers an app directly, bypassing the API.
+func (f *fakeBitrise) AddApp(app App) *App {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if app.Slug == "" {
+		app.Slug = f.newSlug()
+	}
+	f.apps = append(f.apps, &app)
+	return &app
+}
+
+// AddSecret stores a secret of the app directly, bypassing the API.
+func (f *fakeBitrise) AddSecret(appSlug, name string) {
+	f.AddSecretValue(appSlug, name, "value", false)
+}
+
+// AddSecretValue stores a secret of the app with the given value directly,
+// bypassing the API.
+func (f *fakeBitrise) AddSecretValue(appSlug, name, value string, protected bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.secrets[appSlug] == nil {
+		f.secrets[appSlug] = map[string]*fakeSecret{}
+	}
+	f.secrets[appSlug][name] = &fakeSecret{Name: name, Value: value, IsProtected: protected}
+}
+
+// SecretNames returns the sorted secret names of the app.
+func (f *fakeBitrise) SecretNames(appSlug string) []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	names := []string{}
+	for name := range f.secrets[appSlug] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AddWebhook stores an outgoing webhook of the app directly, bypassing the
+// API.
+func (f *fakeBitrise) AddWebhook(appSlug, webhookUrl string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.webhooks[appSlug] == nil {
+		f.webhooks[appSlug] = map[string]*fakeWebhook{}
+	}
+	slug := f.newSlug()
+	f.webhooks[appSlug][slug] = &fakeWebhook{Slug: slug, Url: webhookUrl}
+}
+
+// WebhookUrls returns the sorted outgoing webhook URLs of the app.
+func (f *fakeBitrise) WebhookUrls(appSlug string) []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	urls := []string{}
+	for _, webhook := range f.webhooks[appSlug] {
+		urls = append(urls, webhook.Url)
+	}
+	sort.Strings(urls)
+	return urls
+}
+
+// Me
+
+func (f *fakeBitrise) getMe(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	writeFakeJSON(w, http.StatusOK, userResponse{Data: f.user})
+}
+
+// Organizations
+
+func (f *fakeBitrise) listOrganizations(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	orgs := []Organization{}
+	for _, org := range f.organizations {
+		orgs = append(orgs, *org)
+	}
+	writeFakeJSON(w, http.StatusOK, organizationListResponse{Data: orgs})
+}
+
+func (f *fakeBitrise) getOrganization(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.restricted[params["org"]] {
+		writeFakeError(w, http.StatusForbidden, "Forbidden")
+		return
+	}
+	org := f.organization(params["org"])
+	if org == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, organizationResponse{Data: *org})
+}
+
+// Members
+
+func (f *fakeBitrise) listMembers(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	members := []OrganizationMember{}
+	for _, member := range f.members[params["org"]] {
+		members = append(members, *member)
+	}
+	writeFakeJSON(w, http.StatusOK, organizationMemberListResponse{Data: members})
+}
+
+func (f *fakeBitrise) inviteMember(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.organization(params["org"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body organizationMemberParams
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	member := &OrganizationMember{Slug: f.newSlug(), Email: body.Email, Role: body.Role, Status: "invited"}
+	f.members[params["org"]] = append(f.members[params["org"]], member)
+	writeFakeJSON(w, http.StatusCreated, organizationMemberResponse{Data: *member})
+}
+
+func (f *fakeBitrise) updateMember(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	var body organizationMemberParams
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	for _, member := range f.members[params["org"]] {
+		if member.Slug == params["member"] {
+			member.Role = body.Role
+			writeFakeJSON(w, http.StatusOK, organizationMemberResponse{Data: *member})
+			return
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (f *fakeBitrise) removeMember(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	members := f.members[params["org"]]
+	for i, member := range members {
+		if member.Slug == params["member"] {
+			f.members[params["org"]] = append(members[:i], members[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "Not Found")
+}
+
+// Groups
+
+func (f *fakeBitrise) group(orgSlug, slug string) *OrganizationGroup {
+	for _, group := range f.groups[orgSlug] {
+		if group.Slug == slug {
+			return group
+		}
+	}
+	return nil
+}
+
+func (f *fakeBitrise) createGroup(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	var body organizationGroupParams
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	group := &OrganizationGroup{Slug: f.newSlug(), Name: body.Name}
+	f.groups[params["org"]] = append(f.groups[params["org"]], group)
+	writeFakeJSON(w, http.StatusCreated, organizationGroupResponse{Data: *group})
+}
+
+func (f *fakeBitrise) getGroup(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	group := f.group(params["org"], params["group"])
+	if group == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, organizationGroupResponse{Data: *group})
+}
+
+func (f *fakeBitrise) updateGroup(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	group := f.group(params["org"], params["group"])
+	if group == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body organizationGroupParams
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	group.Name = body.Name
+	writeFakeJSON(w, http.StatusOK, organizationGroupResponse{Data: *group})
+}
+
+func (f *fakeBitrise) deleteGroup(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	groups := f.groups[params["org"]]
+	for i, group := range groups {
+		if group.Slug == params["group"] {
+			f.groups[params["org"]] = append(groups[:i], groups[i+1:]...)
+			delete(f.groupMembers, params["org"]+"/"+params["group"])
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (f *fakeBitrise) listGroupMembers(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.group(params["org"], params["group"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	members := []OrganizationMember{}
+	for _, member := range f.members[params["org"]] {
+		if f.groupMembers[params["org"]+"/"+params["group"]][member.Slug] {
+			members = append(members, *member)
+		}
+	}
+	writeFakeJSON(w, http.StatusOK, groupMemberListResponse{Data: members})
+}
+
+func (f *fakeBitrise) addGroupMember(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.group(params["org"], params["group"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	key := params["org"] + "/" + params["group"]
+	if f.groupMembers[key] == nil {
+		f.groupMembers[key] = map[string]bool{}
+	}
+	f.groupMembers[key][params["user"]] = true
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeBitrise) removeGroupMember(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	key := params["org"] + "/" + params["group"]
+	if !f.groupMembers[key][params["user"]] {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(f.groupMembers[key], params["user"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Apps
+
+func (f *fakeBitrise) listApps(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	query := r.URL.Query()
+	var matching []App
+	for _, app := range f.apps {
+		if params["org"] != "" && app.Owner.Slug != params["org"] {
+			continue
+		}
+		if title := query.Get("title"); title != "" && !strings.Contains(strings.ToLower(app.Title), strings.ToLower(title)) {
+			continue
+		}
+		if projectType := query.Get("project_type"); projectType != "" && app.ProjectType != projectType {
+			continue
+		}
+		matching = append(matching, *app)
+	}
+
+	start := 0
+	if next := query.Get("next"); next != "" {
+		for i, app := range matching {
+			if app.Slug == next {
+				start = i
+			}
+		}
+	}
+	limit := f.pageSize
+	if value, err := strconv.Atoi(query.Get("limit")); err == nil && value > 0 && value < limit {
+		limit = value
+	}
+	end := start + limit
+	resp := appListResponse{Data: []App{}}
+	if end < len(matching) {
+		resp.Paging.Next = matching[end].Slug
+	} else {
+		end = len(matching)
+	}
+	resp.Data = append(resp.Data, matching[start:end]...)
+	resp.Paging.TotalItemCount = len(matching)
+	resp.Paging.PageItemLimit = limit
+	writeFakeJSON(w, http.StatusOK, resp)
+}
+
+func (f *fakeBitrise) registerApp(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	var body Register
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	if f.restricted[body.OrganizationSlug] {
+		writeFakeError(w, http.StatusForbidden, "Forbidden")
+		return
+	}
+	org := f.organization(body.OrganizationSlug)
+	if org == nil {
+		writeFakeError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+	title := body.Title
+	if title == "" {
+		title = body.GitRepoSlug
+	}
+	app := &App{
+		Slug:      f.newSlug(),
+		Title:     title,
+		Provider:  body.RepoProvider,
+		RepoOwner: body.GitOwner,
+		RepoUrl:   body.RepoUrl,
+		RepoSlug:  body.GitRepoSlug,
+		IsPublic:  body.IsPublic,
+		Owner:     AppOwner{AccountType: "organization", Name: org.Name, Slug: org.Slug},
+	}
+	f.apps = append(f.apps, app)
+	writeFakeJSON(w, http.StatusOK, RegisterResponse{Status: "ok", AppSlug: app.Slug})
+}
+
+func (f *fakeBitrise) finishApp(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	app := f.app(params["app"])
+	if app == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body Finish
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	app.ProjectType = body.ProjectType
+	app.Status = 1
+	f.triggerTokens[app.Slug] = "trigger-" + app.Slug
+	writeFakeJSON(w, http.StatusOK, FinishResponse{
+		Status:            "ok",
+		BuildTriggerToken: f.triggerTokens[app.Slug],
+		BranchName:        "main",
+		WorkflowID:        "primary",
+	})
+}
+
+func (f *fakeBitrise) getApp(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	app := f.app(params["app"])
+	if app == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, appResponse{Data: *app})
+}
+
+func (f *fakeBitrise) updateApp(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	app := f.app(params["app"])
+	if app == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body AppUpdate
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	if body.Title != "" {
+		app.Title = body.Title
+	}
+	if body.ProjectType != "" {
+		app.ProjectType = body.ProjectType
+	}
+	if body.RepoUrl != "" {
+		app.RepoUrl = body.RepoUrl
+	}
+	if body.Provider != "" {
+		app.Provider = body.Provider
+	}
+	if body.GitOwner != "" {
+		app.RepoOwner = body.GitOwner
+	}
+	if body.GitRepoSlug != "" {
+		app.RepoSlug = body.GitRepoSlug
+	}
+	if body.IsPublic != nil {
+		app.IsPublic = *body.IsPublic
+	}
+	writeFakeJSON(w, http.StatusOK, appResponse{Data: *app})
+}
+
+func (f *fakeBitrise) deleteApp(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	for i, app := range f.apps {
+		if app.Slug == params["app"] {
+			f.apps = append(f.apps[:i], f.apps[i+1:]...)
+			delete(f.secrets, app.Slug)
+			delete(f.webhooks, app.Slug)
+			delete(f.files, app.Slug)
+			delete(f.triggerTokens, app.Slug)
+			delete(f.appAddons, app.Slug)
+			for key := range f.appRoles {
+				if strings.HasPrefix(key, app.Slug+"/") {
+					delete(f.appRoles, key)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (f *fakeBitrise) transferApp(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	app := f.app(params["app"])
+	if app == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body AppTransfer
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	if f.restricted[body.OrganizationSlug] {
+		writeFakeError(w, http.StatusForbidden, "You are not an owner of the target organization")
+		return
+	}
+	org := f.organization(body.OrganizationSlug)
+	if org == nil {
+		writeFakeError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+	app.Owner = AppOwner{AccountType: "organization", Name: org.Name, Slug: org.Slug}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TriggerToken returns the current build trigger token of the app.
+func (f *fakeBitrise) TriggerToken(appSlug string) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.triggerTokens[appSlug]
+}
+
+func (f *fakeBitrise) getTriggerToken(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, BuildTriggerToken{Token: f.triggerTokens[params["app"]]})
+}
+
+func (f *fakeBitrise) regenerateTriggerToken(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	f.triggerTokens[params["app"]] = "trigger-" + f.newSlug()
+	writeFakeJSON(w, http.StatusOK, BuildTriggerToken{Token: f.triggerTokens[params["app"]]})
+}
+
+func (f *fakeBitrise) getAppRole(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	groups := f.appRoles[params["app"]+"/"+params["role"]]
+	if groups == nil {
+		groups = []string{}
+	}
+	writeFakeJSON(w, http.StatusOK, AppRole{Groups: groups})
+}
+
+func (f *fakeBitrise) putAppRole(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body AppRole
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	f.appRoles[params["app"]+"/"+params["role"]] = body.Groups
+	writeFakeJSON(w, http.StatusOK, body)
+}
+
+// Test devices
+
+// Devices returns copies of the devices registered for the organization, or
+// for the user when orgSlug is empty.
+func (f *fakeBitrise) Devices(orgSlug string) []TestDevice {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	_, key := f.deviceOwner(map[string]string{"org": orgSlug})
+	devices := []TestDevice{}
+	for _, device := range f.devices[key] {
+		devices = append(devices, *device)
+	}
+	return devices
+}
+
+// AddDevice registers a device directly, bypassing the API.
+func (f *fakeBitrise) AddDevice(orgSlug string, device TestDevice) *TestDevice {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	owner, key := f.deviceOwner(map[string]string{"org": orgSlug})
+	if device.Slug == "" {
+		device.Slug = f.newSlug()
+	}
+	device.Owner = owner
+	f.devices[key] = append(f.devices[key], &device)
+	return &device
+}
+
+// deviceOwner returns the owner name and the devices key of the organization
+// of the route, or of the user on `/me` routes.
+func (f *fakeBitrise) deviceOwner(params map[string]string) (string, string) {
+	if params["org"] == "" {
+		return f.user.Username, "me"
+	}
+	org := f.organization(params["org"])
+	if org == nil {
+		return "", ""
+	}
+	return org.Name, org.Slug
+}
+
+func (f *fakeBitrise) device(params map[string]string) *TestDevice {
+	_, key := f.deviceOwner(params)
+	for _, device := range f.devices[key] {
+		if device.Slug == params["device"] {
+			return device
+		}
+	}
+	return nil
+}
+
+func (f *fakeBitrise) listDevices(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, key := f.deviceOwner(params)
+	if key == "" {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	devices := f.devices[key]
+	start := 0
+	if next := r.URL.Query().Get("next"); next != "" {
+		for i, device := range devices {
+			if device.Slug == next {
+				start = i
+			}
+		}
+	}
+	end := start + f.pageSize
+	resp := testDeviceListResponse{Data: []TestDevice{}}
+	if end < len(devices) {
+		resp.Paging.Next = devices[end].Slug
+	} else {
+		end = len(devices)
+	}
+	for _, device := range devices[start:end] {
+		resp.Data = append(resp.Data, *device)
+	}
+	resp.Paging.TotalItemCount = len(devices)
+	resp.Paging.PageItemLimit = f.pageSize
+	writeFakeJSON(w, http.StatusOK, resp)
+}
+
+func (f *fakeBitrise) registerDevice(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	owner, key := f.deviceOwner(params)
+	if key == "" {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body testDeviceParams
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	for _, device := range f.devices[key] {
+		if strings.EqualFold(device.DeviceId, body.DeviceId) {
+			writeFakeError(w, http.StatusUnprocessableEntity, "Device is already registered")
+			return
+		}
+	}
+	device := &TestDevice{Slug: f.newSlug(), DeviceId: body.DeviceId, DeviceType: body.DeviceType, Name: body.Name, Owner: owner}
+	f.devices[key] = append(f.devices[key], device)
+	writeFakeJSON(w, http.StatusCreated, testDeviceResponse{Data: *device})
+}
+
+func (f *fakeBitrise) getDevice(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	device := f.device(params)
+	if device == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, testDeviceResponse{Data: *device})
+}
+
+func (f *fakeBitrise) updateDevice(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	device := f.device(params)
+	if device == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body testDeviceParams
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	device.Name = body.Name
+	writeFakeJSON(w, http.StatusOK, testDeviceResponse{Data: *device})
+}
+
+func (f *fakeBitrise) deleteDevice(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	_, key := f.deviceOwner(params)
+	for i, device := range f.devices[key] {
+		if device.Slug == params["device"] {
+			f.devices[key] = append(f.devices[key][:i], f.devices[key][i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "Not Found")
+}
+
+// Add-ons
+
+// AppAddon returns a copy of the add-on provisioned for the app, or nil.
+func (f *fakeBitrise) AppAddon(appSlug, addonId string) *AppAddon {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	addon := f.appAddons[appSlug][addonId]
+	if addon == nil {
+		return nil
+	}
+	copied := *addon
+	return &copied
+}
+
+func (f *fakeBitrise) listAddons(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	writeFakeJSON(w, http.StatusOK, addonListResponse{Data: f.addons})
+}
+
+func (f *fakeBitrise) listAppAddons(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	resp := appAddonListResponse{Data: []AppAddon{}}
+	for _, addon := range f.appAddons[params["app"]] {
+		resp.Data = append(resp.Data, *addon)
+	}
+	sort.Slice(resp.Data, func(i, j int) bool { return resp.Data[i].Id < resp.Data[j].Id })
+	writeFakeJSON(w, http.StatusOK, resp)
+}
+
+func (f *fakeBitrise) putAppAddon(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body AppAddonParams
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	for _, addon := range f.addons {
+		if addon.Id != params["addon"] {
+			continue
+		}
+		for _, plan := range addon.Plans {
+			if plan.Id != body.PlanId {
+				continue
+			}
+			if f.appAddons[params["app"]] == nil {
+				f.appAddons[params["app"]] = map[string]*AppAddon{}
+			}
+			provisioned := &AppAddon{Id: addon.Id, Title: addon.Title, IsEnabled: true, Plan: plan}
+			f.appAddons[params["app"]][addon.Id] = provisioned
+			writeFakeJSON(w, http.StatusOK, appAddonResponse{Data: *provisioned})
+			return
+		}
+		writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("Add-on %s has no plan %s", addon.Id, body.PlanId))
+		return
+	}
+	writeFakeError(w, http.StatusNotFound, "Add-on not found")
+}
+
+func (f *fakeBitrise) deleteAppAddon(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.appAddons[params["app"]][params["addon"]] == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(f.appAddons[params["app"]], params["addon"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Builds
+
+// AddBuild stores a build of the app directly, bypassing the API. Builds are
+// listed most recent first, so the last added build is returned first.
+func (f *fakeBitrise) AddBuild(appSlug string, build Build) *Build {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if build.Slug == "" {
+		build.Slug = f.newSlug()
+	}
+	if build.BuildNumber == 0 {
+		build.BuildNumber = int64(len(f.builds[appSlug]) + 1)
+	}
+	f.builds[appSlug] = append([]*Build{&build}, f.builds[appSlug]...)
+	return &build
+}
+
+func (f *fakeBitrise) listBuilds(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	query := r.URL.Query()
+	var matching []Build
+	for _, build := range f.builds[params["app"]] {
+		if branch := query.Get("branch"); branch != "" && build.Branch != branch {
+			continue
+		}
+		if workflow := query.Get("workflow"); workflow != "" && build.TriggeredWorkflow != workflow {
+			continue
+		}
+		if status := query.Get("status"); status != "" && strconv.FormatInt(build.Status, 10) != status {
+			continue
+		}
+		if pullRequest := query.Get("pull_request_id"); pullRequest != "" && strconv.FormatInt(build.PullRequestId, 10) != pullRequest {
+			continue
+		}
+		if !fakeTriggeredWithin(build.TriggeredAt, query.Get("after"), query.Get("before")) {
+			continue
+		}
+		matching = append(matching, *build)
+	}
+
+	start := 0
+	if next := query.Get("next"); next != "" {
+		for i, build := range matching {
+			if build.Slug == next {
+				start = i
+			}
+		}
+	}
+	limit := f.pageSize
+	if value, err := strconv.Atoi(query.Get("limit")); err == nil && value > 0 && value < limit {
+		limit = value
+	}
+	end := start + limit
+	resp := buildListResponse{Data: []Build{}}
+	if end < len(matching) {
+		resp.Paging.Next = matching[end].Slug
+	} else {
+		end = len(matching)
+	}
+	resp.Data = append(resp.Data, matching[start:end]...)
+	resp.Paging.TotalItemCount = len(matching)
+	resp.Paging.PageItemLimit = limit
+	writeFakeJSON(w, http.StatusOK, resp)
+}
+
+// AddArtifact stores an artifact of the build directly, bypassing the API.
+// Its download and install page URLs are derived from its slug.
+func (f *fakeBitrise) AddArtifact(buildSlug string, artifact BuildArtifact) *BuildArtifact {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if artifact.Slug == "" {
+		artifact.Slug = f.newSlug()
+	}
+	artifact.ExpiringDownloadUrl = f.server.URL + "/downloads/" + artifact.Slug + "?expires=3600"
+	if artifact.IsPublicPageEnabled {
+		artifact.PublicInstallPageUrl = f.server.URL + "/install/" + artifact.Slug
+	}
+	f.artifacts[buildSlug] = append(f.artifacts[buildSlug], &artifact)
+	return &artifact
+}
+
+// Build returns a copy of the build, or nil when the app has no such build.
+func (f *fakeBitrise) Build(appSlug, buildSlug string) *Build {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	build := f.build(appSlug, buildSlug)
+	if build == nil {
+		return nil
+	}
+	copied := *build
+	return &copied
+}
+
+func (f *fakeBitrise) build(appSlug, buildSlug string) *Build {
+	for _, build := range f.builds[appSlug] {
+		if build.Slug == buildSlug {
+			return build
+		}
+	}
+	return nil
+}
+
+// listArtifacts pages like the API and, like the API, leaves the URLs out of
+// listed artifacts.
+func (f *fakeBitrise) listArtifacts(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.build(params["app"], params["build"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	artifacts := f.artifacts[params["build"]]
+	start := 0
+	if next := r.URL.Query().Get("next"); next != "" {
+		for i, artifact := range artifacts {
+			if artifact.Slug == next {
+				start = i
+			}
+		}
+	}
+	end := start + f.pageSize
+	resp := buildArtifactListResponse{Data: []BuildArtifact{}}
+	if end < len(artifacts) {
+		resp.Paging.Next = artifacts[end].Slug
+	} else {
+		end = len(artifacts)
+	}
+	for _, artifact := range artifacts[start:end] {
+		listed := *artifact
+		listed.ExpiringDownloadUrl = ""
+		listed.PublicInstallPageUrl = ""
+		resp.Data = append(resp.Data, listed)
+	}
+	resp.Paging.TotalItemCount = len(artifacts)
+	resp.Paging.PageItemLimit = f.pageSize
+	writeFakeJSON(w, http.StatusOK, resp)
+}
+
+func (f *fakeBitrise) getArtifact(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.build(params["app"], params["build"]) != nil {
+		for _, artifact := range f.artifacts[params["build"]] {
+			if artifact.Slug == params["artifact"] {
+				writeFakeJSON(w, http.StatusOK, buildArtifactResponse{Data: *artifact})
+				return
+			}
+		}
+	}
+	writeFakeError(w, http.StatusNotFound, "Not Found")
+}
+
+// abortBuild refuses builds which are not running, like the API.
+func (f *fakeBitrise) abortBuild(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	build := f.build(params["app"], params["build"])
+	if build == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body BuildAbort
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	if build.Status != 0 {
+		writeFakeError(w, http.StatusBadRequest, "Build already finished")
+		return
+	}
+	build.Status = 3
+	build.StatusText = "aborted"
+	build.AbortReason = body.AbortReason
+	writeFakeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// SetBuildLog stores the log of a build as chunks. Archived logs are served
+// whole from an expiring URL, the chunks of running builds a page at a time
+// from the most recent one.
+func (f *fakeBitrise) SetBuildLog(buildSlug string, chunks []string, archived bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.logs[buildSlug] = &fakeBuildLog{chunks: chunks, archived: archived}
+}
+
+func (f *fakeBitrise) getBuildLog(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	log := f.logs[params["build"]]
+	if f.build(params["app"], params["build"]) == nil || log == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if log.archived {
+		writeFakeJSON(w, http.StatusOK, BuildLog{
+			IsArchived:        true,
+			ExpiringRawLogUrl: f.server.URL + "/logs/" + params["build"] + "?expires=3600",
+		})
+		return
+	}
+
+	// Chunk positions stand in for the timestamps of the API.
+	end := len(log.chunks)
+	if before, err := strconv.Atoi(r.URL.Query().Get("before_timestamp")); err == nil && before < end {
+		end = before
+	}
+	start := end - f.pageSize
+	if start < 0 {
+		start = 0
+	}
+	resp := BuildLog{LogChunks: []BuildLogChunk{}}
+	for position := start; position < end; position++ {
+		resp.LogChunks = append(resp.LogChunks, BuildLogChunk{Chunk: log.chunks[position], Position: int64(position)})
+	}
+	if start > 0 {
+		resp.NextBeforeTimestamp = strconv.Itoa(start)
+	}
+	writeFakeJSON(w, http.StatusOK, resp)
+}
+
+// downloadBuildLog serves archived logs like a presigned URL, which refuses
+// requests carrying another authorization.
+func (f *fakeBitrise) downloadBuildLog(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if r.Header.Get("Authorization") != "" {
+		writeFakeError(w, http.StatusBadRequest, "Only one auth mechanism allowed")
+		return
+	}
+	log := f.logs[params["build"]]
+	if log == nil || !log.archived {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(strings.Join(log.chunks, "")))
+}
+
+// fakeTriggeredWithin applies the `after` and `before` UNIX timestamp filters.
+func fakeTriggeredWithin(triggeredAt, after, before string) bool {
+	triggered, err := time.Parse(time.RFC3339, triggeredAt)
+	if err != nil {
+		return after == "" && before == ""
+	}
+	if seconds, err := strconv.ParseInt(after, 10, 64); err == nil && !triggered.After(time.Unix(seconds, 0)) {
+		return false
+	}
+	if seconds, err := strconv.ParseInt(before, 10, 64); err == nil && !triggered.Before(time.Unix(seconds, 0)) {
+		return false
+	}
+	return true
+}
+
+// Secrets
+
+func (f *fakeBitrise) listSecrets(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	secrets := []fakeSecret{}
+	for _, secret := range f.secrets[params["app"]] {
+		listed := *secret
+		listed.Value = ""
+		secrets = append(secrets, listed)
+	}
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"data": secrets})
+}
+
+func (f *fakeBitrise) getSecret(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	secret := f.secrets[params["app"]][params["name"]]
+	if secret == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	read := *secret
+	if read.IsProtected {
+		read.Value = ""
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"data": read})
+}
+
+func (f *fakeBitrise) putSecret(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body fakeSecret
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	body.Name = params["name"]
+	if f.secrets[params["app"]] == nil {
+		f.secrets[params["app"]] = map[string]*fakeSecret{}
+	}
+	f.secrets[params["app"]][body.Name] = &body
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"data": body})
+}
+
+func (f *fakeBitrise) deleteSecret(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.secrets[params["app"]][params["name"]] == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(f.secrets[params["app"]], params["name"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Outgoing webhooks
+
+func (f *fakeBitrise) listWebhooks(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	webhooks := []fakeWebhook{}
+	for _, webhook := range f.webhooks[params["app"]] {
+		webhooks = append(webhooks, *webhook)
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].Slug < webhooks[j].Slug })
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"data": webhooks})
+}
+
+func (f *fakeBitrise) createWebhook(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body fakeWebhook
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	body.Slug = f.newSlug()
+	if f.webhooks[params["app"]] == nil {
+		f.webhooks[params["app"]] = map[string]*fakeWebhook{}
+	}
+	f.webhooks[params["app"]][body.Slug] = &body
+	writeFakeJSON(w, http.StatusCreated, map[string]interface{}{"data": body})
+}
+
+func (f *fakeBitrise) updateWebhook(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	webhook := f.webhooks[params["app"]][params["webhook"]]
+	if webhook == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body fakeWebhook
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	body.Slug = webhook.Slug
+	*webhook = body
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"data": body})
+}
+
+func (f *fakeBitrise) deleteWebhook(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.webhooks[params["app"]][params["webhook"]] == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(f.webhooks[params["app"]], params["webhook"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Generic project file uploads
+
+func (f *fakeBitrise) listFiles(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	files := []fakeFile{}
+	for _, file := range f.files[params["app"]] {
+		listed := *file
+		listed.UploadUrl = ""
+		files = append(files, listed)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Slug < files[j].Slug })
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"data": files})
+}
+
+func (f *fakeBitrise) createFile(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body fakeFile
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	body.Slug = f.newSlug()
+	body.UploadUrl = f.server.URL + "/uploads/" + params["app"] + "/" + body.Slug
+	if f.files[params["app"]] == nil {
+		f.files[params["app"]] = map[string]*fakeFile{}
+	}
+	f.files[params["app"]][body.Slug] = &body
+	writeFakeJSON(w, http.StatusCreated, map[string]interface{}{"data": body})
+}
+
+func (f *fakeBitrise) getFile(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	file := f.files[params["app"]][params["file"]]
+	if file == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"data": file})
+}
+
+func (f *fakeBitrise) uploadFile(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	file := f.files[params["app"]][params["file"]]
+	if file == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	content, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	file.content = content
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeBitrise) confirmFile(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	file := f.files[params["app"]][params["file"]]
+	if file == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if int64(len(file.content)) != file.UploadFileSize {
+		writeFakeError(w, http.StatusUnprocessableEntity, "uploaded file size does not match")
+		return
+	}
+	file.IsUploaded = true
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"data": file})
+}
+
+func (f *fakeBitrise) deleteFile(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.files[params["app"]][params["file"]] == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(f.files[params["app"]], params["file"])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Avatar returns the content of the promoted avatar of the app.
+func (f *fakeBitrise) Avatar(appSlug string) []byte {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]byte(nil), f.avatarContent[appSlug]...)
+}
+
+func (f *fakeBitrise) createAvatarCandidates(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if f.app(params["app"]) == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body []AvatarCandidate
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	if f.avatars[params["app"]] == nil {
+		f.avatars[params["app"]] = map[string]*fakeAvatarCandidate{}
+	}
+	candidates := []AvatarCandidate{}
+	for _, candidate := range body {
+		candidate.Slug = f.newSlug()
+		candidate.UploadUrl = f.server.URL + "/avatar-uploads/" + params["app"] + "/" + candidate.Slug
+		f.avatars[params["app"]][candidate.Slug] = &fakeAvatarCandidate{AvatarCandidate: candidate}
+		candidates = append(candidates, candidate)
+	}
+	writeFakeJSON(w, http.StatusCreated, map[string]interface{}{"data": candidates})
+}
+
+func (f *fakeBitrise) uploadAvatarCandidate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	candidate := f.avatars[params["app"]][params["candidate"]]
+	if candidate == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	content, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	candidate.content = content
+	w.WriteHeader(http.StatusOK)
+}
+
+// promoteAvatarCandidate makes the uploaded candidate the avatar of the app,
+// which is served from a URL derived from the candidate slug.
+func (f *fakeBitrise) promoteAvatarCandidate(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	app := f.app(params["app"])
+	candidate := f.avatars[params["app"]][params["candidate"]]
+	if app == nil || candidate == nil {
+		writeFakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var body AvatarPromote
+	if !decodeFake(w, r, &body) {
+		return
+	}
+	if int64(len(candidate.content)) != candidate.Filesize {
+		writeFakeError(w, http.StatusUnprocessableEntity, "uploaded avatar size does not match")
+		return
+	}
+	if body.IsPromoted {
+		app.AvatarUrl = f.server.URL + "/avatars/" + candidate.Slug + filepath.Ext(candidate.Filename)
+		f.avatarContent[app.Slug] = candidate.content
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"data": candidate.AvatarCandidate})
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGroupMembershipResource(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fake.providerConfig() + testAccGroupMembershipResourceConfig(fake.user.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("bitrise_group_membership.test", "group_slug", "bitrise_organization_group.test", "id"),
+					resource.TestCheckResourceAttr("bitrise_group_membership.test", "user_slug", fake.user.Slug),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bitrise_group_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccGroupMembershipResourceConfig(userSlug string) string {
+	return fmt.Sprintf(`
+resource "bitrise_organization_group" "test" {
+  organization_slug = %[1]q
+  name              = "mobile"
+}
+
+resource "bitrise_group_membership" "test" {
+  organization_slug = %[1]q
+  group_slug        = bitrise_organization_group.test.id
+  user_slug         = %[2]q
+}
+`, fakeOrgSlug, userSlug)
+}
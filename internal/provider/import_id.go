@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseImportID splits a composite import identifier of the form
+// `part1/part2/...` into exactly as many parts as names, reporting the
+// expected format on mismatch.
+func parseImportID(id string, names ...string) ([]string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != len(names) {
+		return nil, fmt.Errorf("expected import identifier with format %s, got: %q", strings.Join(names, "/"), id)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("expected import identifier with format %s, got: %q", strings.Join(names, "/"), id)
+		}
+	}
+	return parts, nil
+}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	parts, err := parseImportID("app/admin/group", "app_slug", "role", "group_slug")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := []string{"app", "admin", "group"}; !reflect.DeepEqual(parts, want) {
+		t.Errorf("expected %v, got %v", want, parts)
+	}
+
+	for _, id := range []string{"app/admin", "app/admin/group/extra", "app//group", ""} {
+		if _, err := parseImportID(id, "app_slug", "role", "group_slug"); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &LatestBuildDataSource{}
+
+func NewLatestBuildDataSource() datasource.DataSource {
+	return &LatestBuildDataSource{}
+}
+
+// LatestBuildDataSource defines the data source implementation.
+type LatestBuildDataSource struct {
+	client *BitriseClient
+}
+
+// LatestBuildDataSourceModel describes the data source data model.
+type LatestBuildDataSourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	AppSlug           types.String `tfsdk:"app_slug"`
+	Workflow          types.String `tfsdk:"workflow"`
+	Slug              types.String `tfsdk:"slug"`
+	BuildNumber       types.Int64  `tfsdk:"build_number"`
+	Status            types.String `tfsdk:"status"`
+	Branch            types.String `tfsdk:"branch"`
+	CommitHash        types.String `tfsdk:"commit_hash"`
+	CommitMessage     types.String `tfsdk:"commit_message"`
+	TriggeredWorkflow types.String `tfsdk:"triggered_workflow"`
+	TriggeredBy       types.String `tfsdk:"triggered_by"`
+	TriggeredAt       types.String `tfsdk:"triggered_at"`
+	StartedAt         types.String `tfsdk:"started_at"`
+	FinishedAt        types.String `tfsdk:"finished_at"`
+	DurationSeconds   types.Int64  `tfsdk:"duration_seconds"`
+	PullRequestId     types.Int64  `tfsdk:"pull_request_id"`
+}
+
+func (d *LatestBuildDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_latest_build"
+}
+
+func (d *LatestBuildDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := buildAttributes()
+	attributes["id"] = schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "SLUG of the build",
+	}
+	attributes["app_slug"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "SLUG of the app",
+	}
+	attributes["workflow"] = schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Only consider builds of this workflow",
+	}
+	attributes["branch"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Only consider builds of this branch",
+	}
+	attributes["status"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Only consider builds with this status: `in-progress`, `success`, `error`, `aborted` or `aborted-with-success`. Defaults to `success`.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("in-progress", "success", "error", "aborted", "aborted-with-success"),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Most recent build of an app, by default the last successful one.",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *LatestBuildDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *LatestBuildDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LatestBuildDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status := "success"
+	if !data.Status.IsNull() {
+		status = data.Status.ValueString()
+	}
+	query := url.Values{"status": []string{strconv.FormatInt(buildStatuses[status], 10)}}
+	if !data.Branch.IsNull() {
+		query.Set("branch", data.Branch.ValueString())
+	}
+	if !data.Workflow.IsNull() {
+		query.Set("workflow", data.Workflow.ValueString())
+	}
+
+	builds, err := listBuilds(ctx, d.client, data.AppSlug.ValueString(), query, 1)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list builds, got error: %s", err))
+		return
+	}
+	if len(builds) == 0 {
+		resp.Diagnostics.AddError(
+			"No Build Found",
+			fmt.Sprintf("App %s has no build with status %q matching the filters.", data.AppSlug.ValueString(), status),
+		)
+		return
+	}
+
+	build := newBuildsItemModel(builds[0])
+	data.Id = build.Slug
+	data.Slug = build.Slug
+	data.BuildNumber = build.BuildNumber
+	data.Status = build.Status
+	data.Branch = build.Branch
+	data.CommitHash = build.CommitHash
+	data.CommitMessage = build.CommitMessage
+	data.TriggeredWorkflow = build.TriggeredWorkflow
+	data.TriggeredBy = build.TriggeredBy
+	data.TriggeredAt = build.TriggeredAt
+	data.StartedAt = build.StartedAt
+	data.FinishedAt = build.FinishedAt
+	data.DurationSeconds = build.DurationSeconds
+	data.PullRequestId = build.PullRequestId
+
+	tflog.Trace(ctx, "read a latest build data source", map[string]interface{}{"build": data.Slug.ValueString()})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
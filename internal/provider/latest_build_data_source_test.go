@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccLatestBuildDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+	app := fake.AddApp(App{Title: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	release := fake.AddBuild(app.Slug, Build{
+		Branch: "main", TriggeredWorkflow: "release", Status: 1, StatusText: "success",
+		TriggeredAt: "2023-04-01T10:00:00Z", StartedOnWorkerAt: "2023-04-01T10:00:30Z", FinishedAt: "2023-04-01T10:12:30Z",
+	})
+	fake.AddBuild(app.Slug, Build{
+		Branch: "main", TriggeredWorkflow: "primary", Status: 1, StatusText: "success",
+		TriggeredAt: "2023-04-02T10:00:00Z",
+	})
+	feature := fake.AddBuild(app.Slug, Build{
+		Branch: "feature", TriggeredWorkflow: "primary", Status: 1, StatusText: "success",
+		TriggeredAt: "2023-04-03T10:00:00Z",
+	})
+	failed := fake.AddBuild(app.Slug, Build{
+		Branch: "main", TriggeredWorkflow: "release", Status: 2, StatusText: "error",
+		TriggeredAt: "2023-04-04T10:00:00Z",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Defaults to the last successful build of any branch
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_latest_build" "test" {
+  app_slug = %q
+}
+`, app.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_latest_build.test", "id", feature.Slug),
+					resource.TestCheckResourceAttr("data.bitrise_latest_build.test", "branch", "feature"),
+					resource.TestCheckResourceAttr("data.bitrise_latest_build.test", "status", "success"),
+				),
+			},
+			// Branch and workflow filters
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_latest_build" "test" {
+  app_slug = %q
+  branch   = "main"
+  workflow = "release"
+}
+`, app.Slug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_latest_build.test", "id", release.Slug),
+					resource.TestCheckResourceAttr("data.bitrise_latest_build.test", "build_number", "1"),
+					resource.TestCheckResourceAttr("data.bitrise_latest_build.test", "duration_seconds", "720"),
+				),
+			},
+			// Status filter
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_latest_build" "test" {
+  app_slug = %q
+  status   = "error"
+}
+`, app.Slug),
+				Check: resource.TestCheckResourceAttr("data.bitrise_latest_build.test", "id", failed.Slug),
+			},
+			// No matching build
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_latest_build" "test" {
+  app_slug = %q
+  workflow = "nightly"
+}
+`, app.Slug),
+				ExpectError: regexp.MustCompile("No Build Found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// httpSubsystem is the tflog subsystem of the API traffic, enabled with
+	// `TF_LOG=DEBUG` or `TF_LOG_PROVIDER_BITRISE_HTTP=DEBUG`.
+	httpSubsystem = "http"
+
+	// maxLoggedBody caps the size of logged request and response bodies.
+	maxLoggedBody = 16 * 1024
+
+	redacted = "***"
+)
+
+// redactedFields are JSON keys whose values never reach the logs: tokens,
+// secret values, passwords, certificate contents and presigned URLs.
+var redactedFields = map[string]bool{
+	"token":                 true,
+	"build_trigger_token":   true,
+	"value":                 true,
+	"password":              true,
+	"certificate":           true,
+	"certificate_password":  true,
+	"upload_url":            true,
+	"download_url":          true,
+	"expiring_download_url": true,
+	"expiring_raw_log_url":  true,
+}
+
+// redactedQueryPrefixes and redactedQueryParams match the query parameters
+// presigned S3, GCS and CloudFront URLs carry their credentials in.
+var (
+	redactedQueryPrefixes = []string{"x-amz-", "x-goog-"}
+	redactedQueryParams   = map[string]bool{
+		"signature":      true,
+		"sig":            true,
+		"token":          true,
+		"policy":         true,
+		"key-pair-id":    true,
+		"awsaccesskeyid": true,
+		"googleaccessid": true,
+	}
+)
+
+// redactedHeaders are HTTP headers whose values never reach the logs.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// loggingTransport logs every attempt sent to the Bitrise API with its
+// status, latency and redacted headers and bodies.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func newLoggingTransport(next http.RoundTripper) *loggingTransport {
+	return &loggingTransport{next: next}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), httpSubsystem)
+
+	reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	tflog.SubsystemDebug(ctx, httpSubsystem, "sending Bitrise API request", map[string]interface{}{
+		"method":  req.Method,
+		"url":     redactURL(req.URL),
+		"headers": redactHeaders(req.Header),
+		"body":    redactBody(req.Header.Get("Content-Type"), reqBody),
+	})
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	if err != nil {
+		tflog.SubsystemDebug(ctx, httpSubsystem, "Bitrise API request failed", map[string]interface{}{
+			"method":  req.Method,
+			"url":     redactURL(req.URL),
+			"latency": latency.String(),
+			"error":   err.Error(),
+		})
+		return nil, err
+	}
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	tflog.SubsystemDebug(ctx, httpSubsystem, "received Bitrise API response", map[string]interface{}{
+		"method":  req.Method,
+		"url":     redactURL(req.URL),
+		"status":  res.StatusCode,
+		"latency": latency.String(),
+		"headers": redactHeaders(res.Header),
+		"body":    redactBody(res.Header.Get("Content-Type"), resBody),
+	})
+	return res, nil
+}
+
+// peekRequestBody returns the request body without consuming it.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return ioutil.ReadAll(body)
+	}
+	content, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(content))
+	return content, nil
+}
+
+// redactURL renders a URL for the logs with its user info and the credentials
+// of presigned URLs masked. Other query parameters, such as paging cursors,
+// are kept.
+func redactURL(u *url.URL) string {
+	logged := *u
+	if logged.User != nil {
+		logged.User = url.User(redacted)
+	}
+	query := logged.Query()
+	for name := range query {
+		if isRedactedQueryParam(name) {
+			query.Set(name, redacted)
+		}
+	}
+	logged.RawQuery = query.Encode()
+	return logged.String()
+}
+
+func isRedactedQueryParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, prefix := range redactedQueryPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return redactedQueryParams[name]
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	logged := map[string]string{}
+	for name, values := range header {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			logged[name] = redacted
+			continue
+		}
+		logged[name] = strings.Join(values, ", ")
+	}
+	return logged
+}
+
+// redactBody renders a body for the logs. JSON bodies are logged with the
+// values of redactedFields masked, anything else (file uploads, certificates)
+// only by size.
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var decoded interface{}
+	if (mediaType != "" && mediaType != "application/json") || json.Unmarshal(body, &decoded) != nil {
+		return fmt.Sprintf("<%d bytes of %s>", len(body), contentTypeOrUnknown(mediaType))
+	}
+	masked, err := json.Marshal(redactValue(decoded))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of %s>", len(body), contentTypeOrUnknown(mediaType))
+	}
+	if len(masked) > maxLoggedBody {
+		return string(masked[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(masked)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if redactedFields[strings.ToLower(key)] {
+				typed[key] = redacted
+				continue
+			}
+			typed[key] = redactValue(nested)
+		}
+		return typed
+	case []interface{}:
+		for i, nested := range typed {
+			typed[i] = redactValue(nested)
+		}
+		return typed
+	default:
+		return value
+	}
+}
+
+func contentTypeOrUnknown(mediaType string) string {
+	if mediaType == "" {
+		return "unknown content"
+	}
+	return mediaType
+}
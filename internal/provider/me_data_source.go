@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MeDataSource{}
+
+func NewMeDataSource() datasource.DataSource {
+	return &MeDataSource{}
+}
+
+// MeDataSource defines the data source implementation.
+type MeDataSource struct {
+	client *BitriseClient
+}
+
+// User is the owner of the access token as returned by `GET /me`.
+type User struct {
+	Slug      string `json:"slug"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	AvatarUrl string `json:"avatar_url"`
+}
+
+type userResponse struct {
+	Data User `json:"data"`
+}
+
+// MeDataSourceModel describes the data source data model.
+type MeDataSourceModel struct {
+	Id            types.String          `tfsdk:"id"`
+	Slug          types.String          `tfsdk:"slug"`
+	Username      types.String          `tfsdk:"username"`
+	Email         types.String          `tfsdk:"email"`
+	AvatarUrl     types.String          `tfsdk:"avatar_url"`
+	Organizations []MeOrganizationModel `tfsdk:"organizations"`
+}
+
+// MeOrganizationModel describes an organization accessible to the user.
+type MeOrganizationModel struct {
+	Slug types.String `tfsdk:"slug"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (d *MeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_me"
+}
+
+func (d *MeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Describes the user owning the configured access token.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the user",
+			},
+			"slug": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the user",
+			},
+			"username": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Username of the user",
+			},
+			"email": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Email address of the user",
+			},
+			"avatar_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "URL of the user avatar",
+			},
+			"organizations": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Organizations accessible to the user",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"slug": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SLUG of the organization",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the organization",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *MeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *MeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MeDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := getMe(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the current user, got error: %s", err))
+		return
+	}
+
+	orgs, err := listOrganizations(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list organizations, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(user.Slug)
+	data.Slug = types.StringValue(user.Slug)
+	data.Username = types.StringValue(user.Username)
+	data.Email = types.StringValue(user.Email)
+	data.AvatarUrl = types.StringValue(user.AvatarUrl)
+	data.Organizations = []MeOrganizationModel{}
+	for _, org := range orgs {
+		data.Organizations = append(data.Organizations, MeOrganizationModel{
+			Slug: types.StringValue(org.Slug),
+			Name: types.StringValue(org.Name),
+		})
+	}
+
+	tflog.Trace(ctx, "read a me data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func getMe(ctx context.Context, client *BitriseClient) (*User, error) {
+	respStruct := userResponse{}
+	err := client.do(ctx, http.MethodGet, "/me", nil, nil, &respStruct)
+	if err != nil {
+		return nil, err
+	}
+	return &respStruct.Data, nil
+}
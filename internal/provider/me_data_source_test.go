@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMeDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "bitrise_me" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_me.test", "slug", fake.user.Slug),
+					resource.TestCheckResourceAttr("data.bitrise_me.test", "username", fake.user.Username),
+					resource.TestCheckResourceAttr("data.bitrise_me.test", "organizations.#", "2"),
+				),
+			},
+		},
+	})
+}
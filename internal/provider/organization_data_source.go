@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationDataSource{}
+
+func NewOrganizationDataSource() datasource.DataSource {
+	return &OrganizationDataSource{}
+}
+
+// OrganizationDataSource defines the data source implementation.
+type OrganizationDataSource struct {
+	client *BitriseClient
+}
+
+// Organization is an organization (workspace) as returned by the Bitrise API.
+type Organization struct {
+	Slug             string              `json:"slug"`
+	Name             string              `json:"name"`
+	Plan             string              `json:"plan"`
+	ConcurrencyCount int64               `json:"concurrency_count"`
+	MembersCount     int64               `json:"members_count"`
+	AvatarIconUrl    string              `json:"avatar_icon_url"`
+	Owners           []OrganizationOwner `json:"owners"`
+}
+
+// OrganizationOwner is an owner of an organization.
+type OrganizationOwner struct {
+	Slug     string `json:"slug"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+type organizationResponse struct {
+	Data Organization `json:"data"`
+}
+
+type organizationListResponse struct {
+	Data []Organization `json:"data"`
+}
+
+// OrganizationDataSourceModel describes the data source data model.
+type OrganizationDataSourceModel struct {
+	Id               types.String             `tfsdk:"id"`
+	Slug             types.String             `tfsdk:"slug"`
+	Name             types.String             `tfsdk:"name"`
+	Plan             types.String             `tfsdk:"plan"`
+	ConcurrencyCount types.Int64              `tfsdk:"concurrency_count"`
+	MembersCount     types.Int64              `tfsdk:"members_count"`
+	AvatarIconUrl    types.String             `tfsdk:"avatar_icon_url"`
+	Owners           []OrganizationOwnerModel `tfsdk:"owners"`
+}
+
+// OrganizationOwnerModel describes an owner of an organization.
+type OrganizationOwnerModel struct {
+	Slug     types.String `tfsdk:"slug"`
+	Username types.String `tfsdk:"username"`
+	Email    types.String `tfsdk:"email"`
+}
+
+func newOrganizationOwnerModels(owners []OrganizationOwner) []OrganizationOwnerModel {
+	models := []OrganizationOwnerModel{}
+	for _, owner := range owners {
+		models = append(models, OrganizationOwnerModel{
+			Slug:     types.StringValue(owner.Slug),
+			Username: types.StringValue(owner.Username),
+			Email:    types.StringValue(owner.Email),
+		})
+	}
+	return models
+}
+
+// organizationOwnersAttribute is the schema of the owners list shared by the
+// organization data sources.
+func organizationOwnersAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: "Owners of the organization",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"slug": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "SLUG of the user",
+				},
+				"username": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Username of the user",
+				},
+				"email": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Email address of the user",
+				},
+			},
+		},
+	}
+}
+
+func (d *OrganizationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (d *OrganizationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up an organization (workspace) by `slug` or by `name`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the organization",
+			},
+			"slug": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "SLUG of the organization. Conflicts with `name`.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the organization. Must match exactly one organization.",
+			},
+			"plan": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Plan of the organization",
+			},
+			"concurrency_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of concurrent builds available",
+			},
+			"members_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of members of the organization",
+			},
+			"avatar_icon_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "URL of the organization avatar",
+			},
+			"owners": organizationOwnersAttribute(),
+		},
+	}
+}
+
+func (d *OrganizationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrganizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var org *Organization
+	switch {
+	case !data.Slug.IsNull() && !data.Name.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("slug"), "Conflicting Attributes", "Only one of `slug` and `name` can be set.")
+		return
+	case !data.Slug.IsNull():
+		found, err := getOrganization(ctx, d.client, data.Slug.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization %q, got error: %s", data.Slug.ValueString(), err))
+			return
+		}
+		org = found
+	case !data.Name.IsNull():
+		found, err := findOrganizationByName(ctx, d.client, data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find organization %q, got error: %s", data.Name.ValueString(), err))
+			return
+		}
+		org = found
+	default:
+		resp.Diagnostics.AddError("Missing Attribute", "One of `slug` or `name` must be set.")
+		return
+	}
+
+	data.Id = types.StringValue(org.Slug)
+	data.Slug = types.StringValue(org.Slug)
+	data.Name = types.StringValue(org.Name)
+	data.Plan = types.StringValue(org.Plan)
+	data.ConcurrencyCount = types.Int64Value(org.ConcurrencyCount)
+	data.MembersCount = types.Int64Value(org.MembersCount)
+	data.AvatarIconUrl = types.StringValue(org.AvatarIconUrl)
+	data.Owners = newOrganizationOwnerModels(org.Owners)
+
+	tflog.Trace(ctx, "read an organization data source")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func getOrganization(ctx context.Context, client *BitriseClient, slug string) (*Organization, error) {
+	respStruct := organizationResponse{}
+	err := client.do(ctx, http.MethodGet, "/organizations/"+url.PathEscape(slug), nil, nil, &respStruct)
+	if err != nil {
+		return nil, err
+	}
+	return &respStruct.Data, nil
+}
+
+func listOrganizations(ctx context.Context, client *BitriseClient) ([]Organization, error) {
+	respStruct := organizationListResponse{}
+	err := client.do(ctx, http.MethodGet, "/organizations", nil, nil, &respStruct)
+	if err != nil {
+		return nil, err
+	}
+	return respStruct.Data, nil
+}
+
+func findOrganizationByName(ctx context.Context, client *BitriseClient, name string) (*Organization, error) {
+	orgs, err := listOrganizations(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	var matches []Organization
+	for _, org := range orgs {
+		if org.Name == name {
+			matches = append(matches, org)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no organization named %q is accessible with this token", name)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d organizations named %q, use `slug` instead", len(matches), name)
+	}
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestFindOrganizationByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/organizations" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(organizationListResponse{Data: []Organization{
+			{Slug: "o1", Name: "Mobile"},
+			{Slug: "o2", Name: "Web"},
+			{Slug: "o3", Name: "Web"},
+		}})
+	}))
+	defer server.Close()
+
+	client := &BitriseClient{HTTPClient: server.Client(), Endpoint: server.URL, Token: "token"}
+
+	org, err := findOrganizationByName(context.Background(), client, "Mobile")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if org.Slug != "o1" {
+		t.Errorf("expected slug o1, got %s", org.Slug)
+	}
+
+	if _, err := findOrganizationByName(context.Background(), client, "Web"); err == nil {
+		t.Error("expected an error for a duplicated name")
+	}
+
+	if _, err := findOrganizationByName(context.Background(), client, "Backend"); err == nil {
+		t.Error("expected an error for an unknown name")
+	}
+}
+
+func TestAccOrganizationDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "bitrise_organization" "test" {
+  name = "PG Web"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_organization.test", "slug", fakeOtherOrgSlug),
+					resource.TestCheckResourceAttr("data.bitrise_organization.test", "concurrency_count", "2"),
+					resource.TestCheckResourceAttr("data.bitrise_organization.test", "owners.#", "1"),
+					resource.TestCheckResourceAttr("data.bitrise_organization.test", "owners.0.username", fake.user.Username),
+				),
+			},
+		},
+	})
+}
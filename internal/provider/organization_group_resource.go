@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrganizationGroupResource{}
+var _ resource.ResourceWithImportState = &OrganizationGroupResource{}
+
+func NewOrganizationGroupResource() resource.Resource {
+	return &OrganizationGroupResource{}
+}
+
+// OrganizationGroupResource defines the resource implementation.
+type OrganizationGroupResource struct {
+	client *BitriseClient
+}
+
+// OrganizationGroup is a group of users inside an organization.
+type OrganizationGroup struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type organizationGroupResponse struct {
+	Data OrganizationGroup `json:"data"`
+}
+
+type organizationGroupParams struct {
+	Name string `json:"name"`
+}
+
+// OrganizationGroupResourceModel describes the resource data model.
+type OrganizationGroupResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	OrganizationSlug types.String `tfsdk:"organization_slug"`
+	Name             types.String `tfsdk:"name"`
+}
+
+func (r *OrganizationGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_group"
+}
+
+func (r *OrganizationGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Group of users in an organization. Import with `<organization_slug>/<group_slug>`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the group",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG for the organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the group",
+			},
+		},
+	}
+}
+
+func (r *OrganizationGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OrganizationGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *OrganizationGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := organizationGroupResponse{}
+	params := organizationGroupParams{Name: data.Name.ValueString()}
+	err := r.client.do(ctx, http.MethodPost, organizationGroupsPath(data.OrganizationSlug.ValueString()), nil, params, &respStruct)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create organization group, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(respStruct.Data.Slug)
+	data.Name = types.StringValue(respStruct.Data.Name)
+
+	tflog.Trace(ctx, "created an organization group")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *OrganizationGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := organizationGroupResponse{}
+	groupPath := organizationGroupsPath(data.OrganizationSlug.ValueString()) + "/" + url.PathEscape(data.Id.ValueString())
+	err := r.client.do(ctx, http.MethodGet, groupPath, nil, nil, &respStruct)
+	if IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization group, got error: %s", err))
+		return
+	}
+	data.Name = types.StringValue(respStruct.Data.Name)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *OrganizationGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := organizationGroupResponse{}
+	params := organizationGroupParams{Name: data.Name.ValueString()}
+	groupPath := organizationGroupsPath(data.OrganizationSlug.ValueString()) + "/" + url.PathEscape(data.Id.ValueString())
+	err := r.client.do(ctx, http.MethodPatch, groupPath, nil, params, &respStruct)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update organization group, got error: %s", err))
+		return
+	}
+	data.Name = types.StringValue(respStruct.Data.Name)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *OrganizationGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupPath := organizationGroupsPath(data.OrganizationSlug.ValueString()) + "/" + url.PathEscape(data.Id.ValueString())
+	err := r.client.do(ctx, http.MethodDelete, groupPath, nil, nil, nil)
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete organization group, got error: %s", err))
+		return
+	}
+}
+
+func (r *OrganizationGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "organization_slug", "group_slug")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_slug"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+func organizationGroupsPath(orgSlug string) string {
+	return "/organizations/" + url.PathEscape(orgSlug) + "/groups"
+}
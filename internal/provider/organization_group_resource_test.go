@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccOrganizationGroupResource(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fake.providerConfig() + testAccOrganizationGroupResourceConfig("mobile"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitrise_organization_group.test", "id"),
+					resource.TestCheckResourceAttr("bitrise_organization_group.test", "name", "mobile"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bitrise_organization_group.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccOrganizationGroupImportID("bitrise_organization_group.test"),
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: fake.providerConfig() + testAccOrganizationGroupResourceConfig("mobile-team"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_organization_group.test", "name", "mobile-team"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccOrganizationGroupImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", name)
+		}
+		return rs.Primary.Attributes["organization_slug"] + "/" + rs.Primary.ID, nil
+	}
+}
+
+func testAccOrganizationGroupResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "bitrise_organization_group" "test" {
+  organization_slug = %[1]q
+  name              = %[2]q
+}
+`, fakeOrgSlug, name)
+}
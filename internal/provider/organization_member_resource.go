@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrganizationMemberResource{}
+var _ resource.ResourceWithImportState = &OrganizationMemberResource{}
+
+func NewOrganizationMemberResource() resource.Resource {
+	return &OrganizationMemberResource{}
+}
+
+// OrganizationMemberResource defines the resource implementation.
+type OrganizationMemberResource struct {
+	client *BitriseClient
+}
+
+// OrganizationMember is a member or pending invitation of an organization.
+type OrganizationMember struct {
+	Slug     string `json:"slug"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	Status   string `json:"status"`
+}
+
+type organizationMemberResponse struct {
+	Data OrganizationMember `json:"data"`
+}
+
+type organizationMemberListResponse struct {
+	Data []OrganizationMember `json:"data"`
+}
+
+type organizationMemberParams struct {
+	Email string `json:"email,omitempty"`
+	Role  string `json:"role"`
+}
+
+// OrganizationMemberResourceModel describes the resource data model.
+type OrganizationMemberResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	OrganizationSlug types.String `tfsdk:"organization_slug"`
+	Email            types.String `tfsdk:"email"`
+	Role             types.String `tfsdk:"role"`
+	UserSlug         types.String `tfsdk:"user_slug"`
+	Status           types.String `tfsdk:"status"`
+}
+
+func (r *OrganizationMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_member"
+}
+
+func (r *OrganizationMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Invites a user to an organization by email. Import with `<organization_slug>/<email>`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "`<organization_slug>/<email>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_slug": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "SLUG for the organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Email address the invitation is sent to",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Role in the organization, `owner` or `member`",
+				Default:             stringdefault.StaticString("member"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("owner", "member"),
+				},
+			},
+			"user_slug": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the member",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the invitation is still pending (`invited`) or was accepted (`active`)",
+			},
+		},
+	}
+}
+
+func (r *OrganizationMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OrganizationMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *OrganizationMemberResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := organizationMemberResponse{}
+	params := organizationMemberParams{Email: data.Email.ValueString(), Role: data.Role.ValueString()}
+	err := r.client.do(ctx, http.MethodPost, organizationMembersPath(data.OrganizationSlug.ValueString()), nil, params, &respStruct)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to invite organization member, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(data.OrganizationSlug.ValueString() + "/" + data.Email.ValueString())
+	setOrganizationMember(data, &respStruct.Data)
+
+	tflog.Trace(ctx, "created an organization member")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *OrganizationMemberResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := findOrganizationMember(ctx, r.client, data.OrganizationSlug.ValueString(), data.Email.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization member, got error: %s", err))
+		return
+	}
+	if member == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	setOrganizationMember(data, member)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *OrganizationMemberResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := organizationMemberResponse{}
+	params := organizationMemberParams{Role: data.Role.ValueString()}
+	memberPath := organizationMembersPath(data.OrganizationSlug.ValueString()) + "/" + url.PathEscape(data.UserSlug.ValueString())
+	err := r.client.do(ctx, http.MethodPatch, memberPath, nil, params, &respStruct)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update organization member, got error: %s", err))
+		return
+	}
+	setOrganizationMember(data, &respStruct.Data)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *OrganizationMemberResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	memberPath := organizationMembersPath(data.OrganizationSlug.ValueString()) + "/" + url.PathEscape(data.UserSlug.ValueString())
+	err := r.client.do(ctx, http.MethodDelete, memberPath, nil, nil, nil)
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove organization member, got error: %s", err))
+		return
+	}
+}
+
+func (r *OrganizationMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "organization_slug", "email")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_slug"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("email"), parts[1])...)
+}
+
+func organizationMembersPath(orgSlug string) string {
+	return "/organizations/" + url.PathEscape(orgSlug) + "/members"
+}
+
+func setOrganizationMember(data *OrganizationMemberResourceModel, member *OrganizationMember) {
+	data.Role = types.StringValue(member.Role)
+	data.UserSlug = types.StringValue(member.Slug)
+	data.Status = types.StringValue(member.Status)
+}
+
+// findOrganizationMember returns the member or pending invitation with the
+// given email, or nil when there is none.
+func findOrganizationMember(ctx context.Context, client *BitriseClient, orgSlug, email string) (*OrganizationMember, error) {
+	respStruct := organizationMemberListResponse{}
+	err := client.do(ctx, http.MethodGet, organizationMembersPath(orgSlug), nil, nil, &respStruct)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range respStruct.Data {
+		if strings.EqualFold(member.Email, email) {
+			return &member, nil
+		}
+	}
+	return nil, nil
+}
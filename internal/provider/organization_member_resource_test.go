@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOrganizationMemberResource(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: fake.providerConfig() + testAccOrganizationMemberResourceConfig("member"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_organization_member.test", "id", fakeOrgSlug+"/jane@example.com"),
+					resource.TestCheckResourceAttr("bitrise_organization_member.test", "role", "member"),
+					resource.TestCheckResourceAttr("bitrise_organization_member.test", "status", "invited"),
+					resource.TestCheckResourceAttrSet("bitrise_organization_member.test", "user_slug"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bitrise_organization_member.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: fake.providerConfig() + testAccOrganizationMemberResourceConfig("owner"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bitrise_organization_member.test", "role", "owner"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccOrganizationMemberResourceConfig(role string) string {
+	return fmt.Sprintf(`
+resource "bitrise_organization_member" "test" {
+  organization_slug = %[1]q
+  email             = "jane@example.com"
+  role              = %[2]q
+}
+`, fakeOrgSlug, role)
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OrganizationsDataSource{}
+
+func NewOrganizationsDataSource() datasource.DataSource {
+	return &OrganizationsDataSource{}
+}
+
+// OrganizationsDataSource defines the data source implementation.
+type OrganizationsDataSource struct {
+	client *BitriseClient
+}
+
+// OrganizationsDataSourceModel describes the data source data model.
+type OrganizationsDataSourceModel struct {
+	Id            types.String             `tfsdk:"id"`
+	Organizations []OrganizationsItemModel `tfsdk:"organizations"`
+}
+
+// OrganizationsItemModel describes a single organization of the
+// organizations data source.
+type OrganizationsItemModel struct {
+	Slug             types.String             `tfsdk:"slug"`
+	Name             types.String             `tfsdk:"name"`
+	Plan             types.String             `tfsdk:"plan"`
+	ConcurrencyCount types.Int64              `tfsdk:"concurrency_count"`
+	MembersCount     types.Int64              `tfsdk:"members_count"`
+	AvatarIconUrl    types.String             `tfsdk:"avatar_icon_url"`
+	Owners           []OrganizationOwnerModel `tfsdk:"owners"`
+}
+
+func (d *OrganizationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organizations"
+}
+
+func (d *OrganizationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the organizations (workspaces) accessible with the configured token.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the listing",
+			},
+			"organizations": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Accessible organizations",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"slug": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SLUG of the organization",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the organization",
+						},
+						"plan": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Plan of the organization",
+						},
+						"concurrency_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of concurrent builds available",
+						},
+						"members_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of members of the organization",
+						},
+						"avatar_icon_url": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "URL of the organization avatar",
+						},
+						"owners": organizationOwnersAttribute(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *OrganizationsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OrganizationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data OrganizationsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgs, err := listOrganizations(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list organizations, got error: %s", err))
+		return
+	}
+
+	data.Organizations = []OrganizationsItemModel{}
+	for _, org := range orgs {
+		data.Organizations = append(data.Organizations, OrganizationsItemModel{
+			Slug:             types.StringValue(org.Slug),
+			Name:             types.StringValue(org.Name),
+			Plan:             types.StringValue(org.Plan),
+			ConcurrencyCount: types.Int64Value(org.ConcurrencyCount),
+			MembersCount:     types.Int64Value(org.MembersCount),
+			AvatarIconUrl:    types.StringValue(org.AvatarIconUrl),
+			Owners:           newOrganizationOwnerModels(org.Owners),
+		})
+	}
+	data.Id = types.StringValue("organizations")
+
+	tflog.Trace(ctx, "read an organizations data source", map[string]interface{}{"count": len(data.Organizations)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOrganizationsDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + `
+data "bitrise_organizations" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_organizations.test", "organizations.#", "2"),
+					resource.TestCheckResourceAttr("data.bitrise_organizations.test", "organizations.0.slug", fakeOrgSlug),
+					resource.TestCheckResourceAttr("data.bitrise_organizations.test", "organizations.1.name", "PG Web"),
+				),
+			},
+		},
+	})
+}
@@ -2,14 +2,26 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// defaultRequestTimeout bounds each attempt of an API call when
+// `request_timeout` is not configured.
+const defaultRequestTimeout = 60 * time.Second
+
 // Ensure BitriseProvider satisfies various provider interfaces.
 var _ provider.Provider = &BitriseProvider{}
 
@@ -23,6 +35,12 @@ type BitriseProvider struct {
 
 // BitriseProviderModel describes the provider data model.
 type BitriseProviderModel struct {
+	Endpoint       types.String `tfsdk:"endpoint"`
+	Token          types.String `tfsdk:"token"`
+	ValidateToken  types.Bool   `tfsdk:"validate_token"`
+	MaxRetries     types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait   types.String `tfsdk:"retry_max_wait"`
+	RequestTimeout types.String `tfsdk:"request_timeout"`
 }
 
 func (p *BitriseProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -32,7 +50,37 @@ func (p *BitriseProvider) Metadata(ctx context.Context, req provider.MetadataReq
 
 func (p *BitriseProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Attributes: map[string]schema.Attribute{},
+		MarkdownDescription: "Manage [Bitrise](https://bitrise.io) apps, organizations and team access.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Base URL of the Bitrise API. Can also be set with the `BITRISE_API_URL` environment variable. Defaults to `" + defaultEndpoint + "`.",
+			},
+			"token": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Bitrise personal access token. Can also be set with the `BITRISE_TOKEN` environment variable.",
+			},
+			"validate_token": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Check the token against `GET /me` when the provider is configured, so authentication problems are reported once up front. Defaults to `false`.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of retries of a request failing with a 5xx, 429 or network error. Non-idempotent requests such as app registration are only retried when the API cannot have processed them. Defaults to `3`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum wait between two retries as a Go duration, for example `30s`. Defaults to `30s`.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum duration of each attempt of an API call, as a Go duration. Waiting for the rate limit window and between retries does not count against it. Defaults to `60s`. Whole operations are bounded by the `timeouts` block of each resource.",
+			},
+		},
 	}
 }
 
@@ -45,24 +93,149 @@ func (p *BitriseProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	// Configuration values are now available.
-	// if data.Endpoint.IsNull() { /* ... */ }
+	maxRetries := defaultMaxRetries
+	if !data.MaxRetries.IsNull() {
+		maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	retryMaxWait := defaultRetryMaxWait
+	if !data.RetryMaxWait.IsNull() {
+		parsed, err := time.ParseDuration(data.RetryMaxWait.ValueString())
+		if err != nil || parsed <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid Retry Max Wait",
+				fmt.Sprintf("Expected a positive duration such as `30s`, got: %q", data.RetryMaxWait.ValueString()),
+			)
+			return
+		}
+		retryMaxWait = parsed
+	}
+
+	requestTimeout := defaultRequestTimeout
+	if !data.RequestTimeout.IsNull() {
+		parsed, err := time.ParseDuration(data.RequestTimeout.ValueString())
+		if err != nil || parsed <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				fmt.Sprintf("Expected a positive duration such as `60s`, got: %q", data.RequestTimeout.ValueString()),
+			)
+			return
+		}
+		requestTimeout = parsed
+	}
+
+	endpoint := defaultEndpoint
+	if env := os.Getenv("BITRISE_API_URL"); env != "" {
+		endpoint = env
+	}
+	if !data.Endpoint.IsNull() {
+		endpoint = data.Endpoint.ValueString()
+	}
+
+	token := os.Getenv("BITRISE_TOKEN")
+	if !data.Token.IsNull() {
+		token = data.Token.ValueString()
+	}
+
+	// The recorder is meant for acceptance tests, which record API
+	// interactions once and replay them in CI.
+	transport := http.DefaultTransport
+	if mode := os.Getenv(recorderModeEnvVar); mode != "" {
+		recorder, err := newRecorderTransport(transport, mode, os.Getenv(recorderCassetteEnvVar), endpoint)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Recorder Configuration", err.Error())
+			return
+		}
+		transport = recorder
+	}
+
+	client := &BitriseClient{
+		HTTPClient:     &http.Client{Transport: newAPITransport(transport, maxRetries, retryMaxWait, requestTimeout)},
+		Endpoint:       endpoint,
+		Token:          token,
+		RequestTimeout: requestTimeout,
+	}
+
+	if data.ValidateToken.ValueBool() {
+		if data.Token.IsUnknown() {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("token"),
+				"Bitrise Access Token Not Validated",
+				"The token is not known yet, so it could not be validated while configuring the provider.",
+			)
+		} else {
+			validateToken(ctx, client, resp)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
 
-	// Example client configuration for data sources and resources
-	client := http.DefaultClient
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+// validateToken calls `GET /me` and reports a clear diagnostic when the token
+// is missing or rejected by Bitrise.
+func validateToken(ctx context.Context, client *BitriseClient, resp *provider.ConfigureResponse) {
+	if client.Token == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Missing Bitrise Access Token",
+			"Set the `token` provider attribute or the `BITRISE_TOKEN` environment variable to a Bitrise personal access token.",
+		)
+		return
+	}
+
+	user, err := getMe(ctx, client)
+	if apiErr, ok := err.(*APIError); ok && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Invalid Bitrise Access Token",
+			fmt.Sprintf("Bitrise rejected the configured access token: %s. Check that the token has not expired or been revoked.", apiErr),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Validate Bitrise Access Token",
+			fmt.Sprintf("Calling `GET /me` failed: %s", err),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "validated Bitrise access token", map[string]interface{}{"username": user.Username})
+}
+
 func (p *BitriseProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAppResource,
+		NewOrganizationMemberResource,
+		NewOrganizationGroupResource,
+		NewGroupMembershipResource,
+		NewAppGroupAccessResource,
+		NewAppBuildTriggerTokenResource,
+		NewAppAddonResource,
+		NewTestDeviceResource,
+		NewAppAvatarResource,
 	}
 }
 
 func (p *BitriseProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		NewExampleDataSource,
+		NewAppDataSource,
+		NewAppsDataSource,
+		NewOrganizationDataSource,
+		NewOrganizationsDataSource,
+		NewMeDataSource,
+		NewBuildsDataSource,
+		NewLatestBuildDataSource,
+		NewBuildArtifactsDataSource,
+		NewBuildLogDataSource,
+		NewAddonsDataSource,
+		NewTestDevicesDataSource,
 	}
 }
 
@@ -1,8 +1,12 @@
 package provider
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -12,7 +16,7 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"bitrise": providerserver.NewProtocol6WithError(New("test")()),
 }
 
 func testAccPreCheck(t *testing.T) {
@@ -20,3 +24,40 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestValidateToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"slug":"u1","username":"nate"}}`))
+	}))
+	defer server.Close()
+
+	testCases := map[string]struct {
+		token     string
+		wantError string
+	}{
+		"valid":   {token: "valid"},
+		"invalid": {token: "expired", wantError: "Invalid Bitrise Access Token"},
+		"missing": {token: "", wantError: "Missing Bitrise Access Token"},
+	}
+
+	for name, testCase := range testCases {
+		client := &BitriseClient{HTTPClient: server.Client(), Endpoint: server.URL, Token: testCase.token}
+		resp := &provider.ConfigureResponse{}
+		validateToken(context.Background(), client, resp)
+
+		if testCase.wantError == "" {
+			if resp.Diagnostics.HasError() {
+				t.Errorf("%s: unexpected diagnostics: %v", name, resp.Diagnostics)
+			}
+			continue
+		}
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != testCase.wantError {
+			t.Errorf("%s: expected %q error, got %v", name, testCase.wantError, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// rateLimitTransport throttles every request of the provider once the
+// `X-RateLimit-Remaining` budget reported by Bitrise is used up, until the
+// window announced by `X-RateLimit-Reset` is over. A single instance is
+// shared by all resources so Terraform's parallel graph walk cannot exceed
+// the budget.
+type rateLimitTransport struct {
+	next http.RoundTripper
+	now  func() time.Time
+
+	mutex sync.Mutex
+	// remaining is the number of requests left in the current window, or -1
+	// before the API reported it.
+	remaining int
+	reset     time.Time
+}
+
+func newRateLimitTransport(next http.RoundTripper) *rateLimitTransport {
+	return &rateLimitTransport{
+		next:      next,
+		now:       time.Now,
+		remaining: -1,
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for {
+		wait := t.reserve()
+		if wait <= 0 {
+			break
+		}
+		tflog.Debug(ctx, "Bitrise API rate limit reached, waiting for the next window", map[string]interface{}{
+			"wait": wait.String(),
+		})
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err == nil {
+		t.update(res.Header)
+	}
+	return res, err
+}
+
+// reserve takes one request from the budget, or returns how long to wait
+// before trying again when it is exhausted.
+func (t *rateLimitTransport) reserve() time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.remaining < 0 {
+		return 0
+	}
+	if t.remaining == 0 {
+		wait := t.reset.Sub(t.now())
+		if wait > 0 {
+			return wait
+		}
+		// The window is over but nothing reported the new budget yet.
+		t.remaining = -1
+		return 0
+	}
+	t.remaining--
+	return 0
+}
+
+func (t *rateLimitTransport) update(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.remaining = remaining
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		t.reset = parseRateLimitReset(reset, t.now())
+	}
+}
+
+// parseRateLimitReset accepts both a UNIX timestamp and a number of seconds
+// until the window resets.
+func parseRateLimitReset(value int64, now time.Time) time.Time {
+	if value > 1000000000 {
+		return time.Unix(value, 0)
+	}
+	return now.Add(time.Duration(value) * time.Second)
+}
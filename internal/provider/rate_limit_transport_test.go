@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransportReserve(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	transport := newRateLimitTransport(nil)
+	transport.now = func() time.Time { return now }
+
+	if wait := transport.reserve(); wait != 0 {
+		t.Fatalf("expected no wait before the budget is known, got %s", wait)
+	}
+
+	transport.update(http.Header{
+		"X-Ratelimit-Remaining": []string{"1"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(now.Add(30*time.Second).Unix(), 10)},
+	})
+	if wait := transport.reserve(); wait != 0 {
+		t.Fatalf("expected the last request of the window to go through, got %s", wait)
+	}
+	if wait := transport.reserve(); wait != 30*time.Second {
+		t.Fatalf("expected to wait for the window reset, got %s", wait)
+	}
+
+	now = now.Add(31 * time.Second)
+	if wait := transport.reserve(); wait != 0 {
+		t.Fatalf("expected no wait once the window is over, got %s", wait)
+	}
+
+	transport.update(http.Header{
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{"5"},
+	})
+	if wait := transport.reserve(); wait != 5*time.Second {
+		t.Fatalf("expected a relative reset to be supported, got %s", wait)
+	}
+}
+
+func TestRateLimitTransportConcurrent(t *testing.T) {
+	var mutex sync.Mutex
+	var served []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		served = append(served, time.Now())
+		remaining := 0
+		if len(served) > 2 {
+			remaining = 10
+		}
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", "1")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport)}
+	start := time.Now()
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	res.Body.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := client.Get(server.URL)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			res.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if len(served) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(served))
+	}
+	if elapsed := served[1].Sub(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected requests to wait for the window reset, the second one was sent after %s", elapsed)
+	}
+}
@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// recorderModeEnvVar switches the recorder on, with either
+	// recorderModeRecord or recorderModeReplay.
+	recorderModeEnvVar = "BITRISE_RECORDER_MODE"
+	// recorderCassetteEnvVar is the path of the cassette file.
+	recorderCassetteEnvVar = "BITRISE_RECORDER_CASSETTE"
+
+	recorderModeRecord = "record"
+	recorderModeReplay = "replay"
+)
+
+// cassettes are shared by every provider instance of the process, so the
+// Terraform commands run by one acceptance test append to, or replay from,
+// the same cassette. Tests forget their cassette once done, so that the next
+// run starts over.
+var (
+	cassettes      = map[string]*cassette{}
+	cassettesMutex sync.Mutex
+)
+
+// cassette is a recorded sequence of API interactions.
+type cassette struct {
+	path string
+
+	mutex        sync.Mutex
+	Interactions []interaction `json:"interactions"`
+	// replayed counts the interactions already replayed per request key.
+	replayed map[string]int
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode  int             `json:"status_code"`
+	ContentType string          `json:"content_type,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+}
+
+// recorderTransport records the API interactions into a cassette, or answers
+// requests from a recorded cassette without any network access. URLs are
+// stored relative to the endpoint, and tokens and other redactedFields are
+// scrubbed from the bodies, so cassettes can be committed and replayed against
+// any endpoint.
+type recorderTransport struct {
+	next     http.RoundTripper
+	mode     string
+	endpoint string
+	cassette *cassette
+}
+
+func newRecorderTransport(next http.RoundTripper, mode, path, endpoint string) (*recorderTransport, error) {
+	if mode != recorderModeRecord && mode != recorderModeReplay {
+		return nil, fmt.Errorf("%s must be %q or %q, got: %q", recorderModeEnvVar, recorderModeRecord, recorderModeReplay, mode)
+	}
+	if path == "" {
+		return nil, fmt.Errorf("%s must be set when %s is set", recorderCassetteEnvVar, recorderModeEnvVar)
+	}
+	c, err := loadCassette(path, mode)
+	if err != nil {
+		return nil, err
+	}
+	return &recorderTransport{
+		next:     next,
+		mode:     mode,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		cassette: c,
+	}, nil
+}
+
+// loadCassette returns the cassette shared by the process, reading it from
+// disk when it exists. Recording appends to it, since each Terraform command
+// outside the test harness runs a new provider process; delete the file to
+// record from scratch.
+func loadCassette(path, mode string) (*cassette, error) {
+	cassettesMutex.Lock()
+	defer cassettesMutex.Unlock()
+
+	if c, ok := cassettes[path]; ok {
+		return c, nil
+	}
+	c := &cassette{path: path, replayed: map[string]int{}}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && mode == recorderModeRecord {
+		cassettes[path] = c
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(content, c); err != nil {
+		return nil, fmt.Errorf("unable to parse cassette %s: %w", path, err)
+	}
+	// Bodies are indented in the file, but compact in live requests.
+	for n, i := range c.Interactions {
+		if len(i.Request.Body) == 0 {
+			continue
+		}
+		var body bytes.Buffer
+		if err := json.Compact(&body, i.Request.Body); err != nil {
+			return nil, fmt.Errorf("unable to parse cassette %s: %w", path, err)
+		}
+		c.Interactions[n].Request.Body = body.Bytes()
+	}
+	cassettes[path] = c
+	return c, nil
+}
+
+// forgetCassette drops the cassette shared by the process, so that it is
+// loaded again, from the first interaction, by the next provider instance.
+func forgetCassette(path string) {
+	cassettesMutex.Lock()
+	defer cassettesMutex.Unlock()
+
+	delete(cassettes, path)
+}
+
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := recordedRequest{
+		Method: req.Method,
+		URL:    t.relativeURL(req),
+		Body:   scrubBody(req.Header.Get("Content-Type"), reqBody),
+	}
+
+	if t.mode == recorderModeReplay {
+		response, ok := t.cassette.replay(recorded)
+		if !ok {
+			return nil, fmt.Errorf("no interaction recorded in %s for %s %s with body %s", t.cassette.path, recorded.Method, recorded.URL, recorded.Body)
+		}
+		header := http.Header{}
+		if response.ContentType != "" {
+			header.Set("Content-Type", response.ContentType)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+			StatusCode:    response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(response.Body)),
+			ContentLength: int64(len(response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	contentType := res.Header.Get("Content-Type")
+	err = t.cassette.record(interaction{
+		Request: recorded,
+		Response: recordedResponse{
+			StatusCode:  res.StatusCode,
+			ContentType: contentType,
+			Body:        scrubBody(contentType, resBody),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// relativeURL strips the endpoint from API URLs. Other URLs, such as
+// presigned upload URLs, lose their query string, which holds the signature.
+func (t *recorderTransport) relativeURL(req *http.Request) string {
+	full := req.URL.String()
+	if t.endpoint != "" && strings.HasPrefix(full, t.endpoint) {
+		return strings.TrimPrefix(full, t.endpoint)
+	}
+	stripped := *req.URL
+	stripped.User = nil
+	stripped.RawQuery = ""
+	stripped.ForceQuery = false
+	return stripped.String()
+}
+
+// record appends an interaction and saves the cassette right away, since the
+// provider process may end with any Terraform command.
+func (c *cassette) record(i interaction) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.Interactions = append(c.Interactions, i)
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, append(content, '\n'), 0644)
+}
+
+// replay returns the next recorded response to the same method, URL and body.
+// Requests repeated more often than recorded get the last response again.
+func (c *cassette) replay(req recordedRequest) (recordedResponse, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := req.Method + " " + req.URL + " " + string(req.Body)
+	var matches []interaction
+	for _, i := range c.Interactions {
+		if i.Request.Method == req.Method && i.Request.URL == req.URL && bytes.Equal(i.Request.Body, req.Body) {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return recordedResponse{}, false
+	}
+	n := c.replayed[key]
+	c.replayed[key] = n + 1
+	if n >= len(matches) {
+		n = len(matches) - 1
+	}
+	return matches[n].Response, true
+}
+
+// scrubBody masks the redactedFields of JSON bodies. Other bodies, such as
+// file uploads, are not recorded.
+func scrubBody(contentType string, body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var decoded interface{}
+	if (mediaType != "" && mediaType != "application/json") || json.Unmarshal(body, &decoded) != nil {
+		return nil
+	}
+	scrubbed, err := json.Marshal(redactValue(decoded))
+	if err != nil {
+		return nil
+	}
+	return scrubbed
+}
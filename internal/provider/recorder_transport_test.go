@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRecorderTransport(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			_, _ = w.Write([]byte(`{"data":{"slug":"a1","title":"before"},"build_trigger_token":"secret"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"slug":"a1","title":"after"}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	get := func(transport http.RoundTripper, endpoint string) string {
+		req, err := http.NewRequest(http.MethodGet, endpoint+"/apps/a1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "token")
+		res, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	recorder, err := newRecorderTransport(http.DefaultTransport, recorderModeRecord, path, server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if body := get(recorder, server.URL); !strings.Contains(body, "secret") {
+		t.Errorf("expected the live response while recording, got %s", body)
+	}
+	get(recorder, server.URL)
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leaked := range []string{"secret", server.URL} {
+		if strings.Contains(string(content), leaked) {
+			t.Errorf("expected %q to be scrubbed from the cassette, got %s", leaked, content)
+		}
+	}
+
+	// Replay from disk, against another endpoint and without the server.
+	forgetCassette(path)
+	server.Close()
+	replayer, err := newRecorderTransport(http.DefaultTransport, recorderModeReplay, path, "https://api.example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, want := range []string{"before", "after", "after"} {
+		if body := get(replayer, "https://api.example.com"); !strings.Contains(body, want) {
+			t.Errorf("expected replayed response with %q, got %s", want, body)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, "https://api.example.com/apps/a1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := replayer.RoundTrip(req); err == nil {
+		t.Error("expected an error for a request missing from the cassette")
+	}
+}
+
+func TestNewRecorderTransportInvalid(t *testing.T) {
+	if _, err := newRecorderTransport(http.DefaultTransport, "rewind", "cassette.json", ""); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+	if _, err := newRecorderTransport(http.DefaultTransport, recorderModeRecord, "", ""); err == nil {
+		t.Error("expected an error without a cassette")
+	}
+}
+
+func TestRecorderTransportReplayMatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	content := `{
+  "interactions": [
+    {
+      "request": {"method": "PATCH", "url": "/apps/a1", "body": {"title": "first"}},
+      "response": {"status_code": 200, "content_type": "application/json", "body": {"title": "first"}}
+    },
+    {
+      "request": {"method": "PATCH", "url": "/apps/a1", "body": {"title": "second"}},
+      "response": {"status_code": 200, "content_type": "application/json", "body": {"title": "second"}}
+    },
+    {
+      "request": {"method": "PUT", "url": "https://uploads.example.com/avatar.png"},
+      "response": {"status_code": 200}
+    }
+  ]
+}`
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	roundTrip := func(transport http.RoundTripper, method, url, body string) (string, error) {
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		res, err := transport.RoundTrip(req)
+		if err != nil {
+			return "", err
+		}
+		defer res.Body.Close()
+		resBody, err := ioutil.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(resBody), nil
+	}
+
+	// Each run replays the cassette from the start, as with -count=2.
+	for run := 0; run < 2; run++ {
+		replayer, err := newRecorderTransport(http.DefaultTransport, recorderModeReplay, path, "https://api.example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		for _, title := range []string{"second", "first"} {
+			body, err := roundTrip(replayer, http.MethodPatch, "https://api.example.com/apps/a1", `{"title":"`+title+`"}`)
+			if err != nil {
+				t.Fatalf("run %d: unexpected error: %s", run, err)
+			}
+			if !strings.Contains(body, title) {
+				t.Errorf("run %d: expected the response recorded for %q, got %s", run, title, body)
+			}
+		}
+		if _, err := roundTrip(replayer, http.MethodPatch, "https://api.example.com/apps/a1", `{"title":"third"}`); err == nil {
+			t.Errorf("run %d: expected an error for a body missing from the cassette", run)
+		}
+		if _, err := roundTrip(replayer, http.MethodPut, "https://uploads.example.com/avatar.png?X-Amz-Signature=run"+strconv.Itoa(run), ""); err != nil {
+			t.Errorf("run %d: expected presigned URLs to match without their query string, got %s", run, err)
+		}
+		forgetCassette(path)
+	}
+}
+
+// TestRecorderTransportAppends checks that recording keeps the interactions of
+// previous provider processes, which only share the cassette file.
+func TestRecorderTransportAppends(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"slug":"a1"}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		recorder, err := newRecorderTransport(http.DefaultTransport, recorderModeRecord, path, server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		req, err := http.NewRequest(method, server.URL+"/apps/a1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := recorder.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		res.Body.Close()
+		forgetCassette(path)
+	}
+
+	c, err := loadCassette(path, recorderModeReplay)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer forgetCassette(path)
+	if len(c.Interactions) != 2 {
+		t.Errorf("expected the interactions of both processes, got %d", len(c.Interactions))
+	}
+}
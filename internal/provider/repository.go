@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Git repository providers of bitrise_app.
+const (
+	repoProviderGitHub           = "github"
+	repoProviderGitLab           = "gitlab"
+	repoProviderBitbucket        = "bitbucket"
+	repoProviderGitLabSelfHosted = "gitlab-self-hosted"
+	repoProviderBitbucketServer  = "bitbucket-server"
+	repoProviderCustom           = "custom"
+)
+
+var repoProviders = []string{
+	repoProviderGitHub,
+	repoProviderGitLab,
+	repoProviderBitbucket,
+	repoProviderGitLabSelfHosted,
+	repoProviderBitbucketServer,
+	repoProviderCustom,
+}
+
+// repoProviderHosts are the hosts of the hosted providers, which also decide
+// the provider of an app whose `repo_provider` is not set.
+var repoProviderHosts = map[string]string{
+	repoProviderGitHub:    "github.com",
+	repoProviderGitLab:    "gitlab.com",
+	repoProviderBitbucket: "bitbucket.org",
+}
+
+// selfHostedRepoProviders maps the self-hosted providers to their hosted
+// counterpart.
+var selfHostedRepoProviders = map[string]string{
+	repoProviderGitLabSelfHosted: repoProviderGitLab,
+	repoProviderBitbucketServer:  repoProviderBitbucket,
+}
+
+// scpLikeRegex matches the scp-like syntax of SSH URLs, `git@host:owner/repo.git`.
+var scpLikeRegex = regexp.MustCompile(`^(?:[\w.~-]+@)?([\w.-]+):([^/].*)$`)
+
+// repoURL is a parsed git repository URL.
+type repoURL struct {
+	SSH  bool
+	Host string
+	// Path holds the segments of the path, without the `.git` suffix.
+	Path []string
+}
+
+// parseRepoURL parses the HTTPS and SSH URLs git clones from, including the
+// scp-like syntax of SSH. Whether the path must also hold an owner depends on
+// the provider, see repoOwnerAndSlug.
+func parseRepoURL(raw string) (*repoURL, error) {
+	repo := &repoURL{}
+	var repoPath string
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repository URL %q: %w", raw, err)
+		}
+		switch u.Scheme {
+		case "https", "http":
+		case "ssh":
+			repo.SSH = true
+		default:
+			return nil, fmt.Errorf("repository URL %q must use https or ssh, got %s", raw, u.Scheme)
+		}
+		repo.Host = u.Hostname()
+		repoPath = u.Path
+	} else {
+		match := scpLikeRegex.FindStringSubmatch(raw)
+		if match == nil {
+			return nil, fmt.Errorf("repository URL %q must be an HTTPS URL, an ssh:// URL or of the form git@host:owner/repository.git", raw)
+		}
+		repo.SSH = true
+		repo.Host = match[1]
+		repoPath = match[2]
+	}
+	if repo.Host == "" {
+		return nil, fmt.Errorf("repository URL %q has no host", raw)
+	}
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	repo.Path = strings.Split(repoPath, "/")
+	for _, segment := range repo.Path {
+		if segment == "" {
+			return nil, fmt.Errorf("repository URL %q must have a repository in its path, without empty segments", raw)
+		}
+	}
+	return repo, nil
+}
+
+// inferRepoProvider returns the provider hosting repo, which is only known for
+// the hosted providers.
+func inferRepoProvider(repo *repoURL) (string, error) {
+	for provider, host := range repoProviderHosts {
+		if strings.EqualFold(repo.Host, host) {
+			return provider, nil
+		}
+	}
+	return "", fmt.Errorf("the provider of repositories on %s is unknown, set repo_provider to %s, %s or %s",
+		repo.Host, repoProviderGitLabSelfHosted, repoProviderBitbucketServer, repoProviderCustom)
+}
+
+// repoOwnerAndSlug returns the owner and the name of repo according to the URL
+// format of provider, checking that its host belongs to the provider.
+//
+// GitHub and Bitbucket repositories are `<owner>/<repository>`. GitLab ones
+// may sit in nested groups, which all make up the owner. Bitbucket Server has
+// project keys as owners, under `/scm/` when cloned over HTTPS. Custom
+// repositories may have no owner in their path, which returns an empty owner.
+func repoOwnerAndSlug(provider string, repo *repoURL) (string, string, error) {
+	if host, ok := repoProviderHosts[provider]; ok && !strings.EqualFold(repo.Host, host) {
+		return "", "", fmt.Errorf("%s repositories are hosted on %s, got %s", provider, host, repo.Host)
+	}
+	if hosted, ok := selfHostedRepoProviders[provider]; ok && strings.EqualFold(repo.Host, repoProviderHosts[hosted]) {
+		return "", "", fmt.Errorf("repositories on %s are not self-hosted, use %s", repo.Host, hosted)
+	}
+
+	segments := repo.Path
+	switch provider {
+	case repoProviderGitHub, repoProviderBitbucket:
+		if len(segments) != 2 {
+			return "", "", fmt.Errorf("%s repository URLs have the path <owner>/<repository>, got %s", provider, strings.Join(segments, "/"))
+		}
+	case repoProviderGitLab, repoProviderGitLabSelfHosted:
+		if len(segments) < 2 {
+			return "", "", fmt.Errorf("%s repository URLs have the path <group>/<repository>, got %s", provider, strings.Join(segments, "/"))
+		}
+	case repoProviderCustom:
+	case repoProviderBitbucketServer:
+		if !repo.SSH && (len(segments) < 3 || segments[len(segments)-3] != "scm") {
+			return "", "", fmt.Errorf("%s HTTPS repository URLs have the path scm/<project>/<repository>, got %s", provider, strings.Join(segments, "/"))
+		}
+		if len(segments) < 2 {
+			return "", "", fmt.Errorf("%s SSH repository URLs have the path <project>/<repository>, got %s", provider, strings.Join(segments, "/"))
+		}
+		segments = segments[len(segments)-2:]
+	default:
+		return "", "", fmt.Errorf("unsupported repository provider %q, expected one of %s", provider, strings.Join(repoProviders, ", "))
+	}
+	last := len(segments) - 1
+	return strings.Join(segments[:last], "/"), segments[last], nil
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestRepoOwnerAndSlug(t *testing.T) {
+	cases := []struct {
+		provider string
+		repoUrl  string
+		owner    string
+		slug     string
+	}{
+		{repoProviderGitHub, "https://github.com/pgdevelopers/mobile.git", "pgdevelopers", "mobile"},
+		{repoProviderGitHub, "git@github.com:pgdevelopers/mobile.git", "pgdevelopers", "mobile"},
+		{repoProviderGitHub, "ssh://git@github.com/pgdevelopers/mobile", "pgdevelopers", "mobile"},
+		{repoProviderBitbucket, "git@bitbucket.org:pgdevelopers/mobile.git", "pgdevelopers", "mobile"},
+		{repoProviderGitLab, "https://gitlab.com/pg/apps/mobile.git", "pg/apps", "mobile"},
+		{repoProviderGitLabSelfHosted, "git@gitlab.example.com:pg/apps/mobile.git", "pg/apps", "mobile"},
+		{repoProviderBitbucketServer, "https://git.example.com/bitbucket/scm/mob/mobile.git", "mob", "mobile"},
+		{repoProviderBitbucketServer, "ssh://git@git.example.com:7999/mob/mobile.git", "mob", "mobile"},
+		{repoProviderCustom, "https://git.example.com/repos/pg/mobile.git", "repos/pg", "mobile"},
+		{repoProviderCustom, "https://git.example.com/mobile.git", "", "mobile"},
+		{repoProviderCustom, "git@git.example.com:mobile.git", "", "mobile"},
+	}
+	for _, c := range cases {
+		repo, err := parseRepoURL(c.repoUrl)
+		if err != nil {
+			t.Errorf("parseRepoURL(%q): unexpected error: %s", c.repoUrl, err)
+			continue
+		}
+		owner, slug, err := repoOwnerAndSlug(c.provider, repo)
+		if err != nil {
+			t.Errorf("repoOwnerAndSlug(%s, %q): unexpected error: %s", c.provider, c.repoUrl, err)
+			continue
+		}
+		if owner != c.owner || slug != c.slug {
+			t.Errorf("repoOwnerAndSlug(%s, %q) = %q, %q, expected %q, %q", c.provider, c.repoUrl, owner, slug, c.owner, c.slug)
+		}
+	}
+}
+
+func TestRepoOwnerAndSlug_invalid(t *testing.T) {
+	cases := []struct {
+		provider string
+		repoUrl  string
+	}{
+		{repoProviderGitHub, "https://gitlab.com/pg/mobile.git"},
+		{repoProviderGitHub, "https://github.com/pg/apps/mobile.git"},
+		{repoProviderGitHub, "https://github.com/mobile.git"},
+		{repoProviderGitLab, "https://gitlab.com/mobile.git"},
+		{repoProviderBitbucketServer, "git@git.example.com:mobile.git"},
+		{repoProviderGitLabSelfHosted, "https://gitlab.com/pg/mobile.git"},
+		{repoProviderBitbucketServer, "https://bitbucket.org/pg/mobile.git"},
+		{repoProviderBitbucketServer, "https://git.example.com/projects/mob/mobile.git"},
+		{"svn", "https://git.example.com/pg/mobile.git"},
+	}
+	for _, c := range cases {
+		repo, err := parseRepoURL(c.repoUrl)
+		if err != nil {
+			t.Errorf("parseRepoURL(%q): unexpected error: %s", c.repoUrl, err)
+			continue
+		}
+		if _, _, err := repoOwnerAndSlug(c.provider, repo); err == nil {
+			t.Errorf("repoOwnerAndSlug(%s, %q): expected an error", c.provider, c.repoUrl)
+		}
+	}
+}
+
+func TestParseRepoURL_invalid(t *testing.T) {
+	for _, repoUrl := range []string{"", "mobile", "github.com/pg/mobile", "ftp://github.com/pg/mobile.git", "https://github.com/", "git@github.com:pg//mobile.git"} {
+		if _, err := parseRepoURL(repoUrl); err == nil {
+			t.Errorf("expected an error for %q", repoUrl)
+		}
+	}
+}
+
+func TestInferRepoProvider(t *testing.T) {
+	for repoUrl, want := range map[string]string{
+		"git@github.com:pg/mobile.git":     repoProviderGitHub,
+		"https://GitLab.com/pg/mobile.git": repoProviderGitLab,
+		"https://bitbucket.org/pg/mobile":  repoProviderBitbucket,
+	} {
+		repo, err := parseRepoURL(repoUrl)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got, err := inferRepoProvider(repo); err != nil || got != want {
+			t.Errorf("inferRepoProvider(%q) = %q, %v, expected %q", repoUrl, got, err, want)
+		}
+	}
+
+	repo, err := parseRepoURL("git@git.example.com:pg/mobile.git")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := inferRepoProvider(repo); err == nil {
+		t.Error("expected an error for a self-hosted repository")
+	}
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryMaxWait = 30 * time.Second
+	defaultRetryMinWait = 1 * time.Second
+)
+
+type idempotentContextKey struct{}
+
+// withIdempotentRetries marks requests made with ctx as safe to retry even
+// when their method is not idempotent, e.g. because the endpoint ignores
+// repeated calls.
+func withIdempotentRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentContextKey{}, true)
+}
+
+// retryTransport retries requests failing with 5xx, 429 or network errors
+// using exponential backoff with full jitter. Non-idempotent requests are
+// only retried when the API cannot have acted on them.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minWait:    defaultRetryMinWait,
+		maxWait:    maxWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	idempotent := isIdempotent(req)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return nil, errors.New("cannot retry request with a non rewindable body")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		res, err := t.next.RoundTrip(attemptReq)
+
+		if ctx.Err() != nil || attempt >= t.maxRetries || !shouldRetry(res, err, idempotent) {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+		tflog.Debug(ctx, "retrying Bitrise API request", map[string]interface{}{
+			"method":  req.Method,
+			"url":     redactURL(req.URL),
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"status":  statusOf(res),
+			"error":   errorOf(err),
+		})
+		if res != nil {
+			_, _ = io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func isIdempotent(req *http.Request) bool {
+	if marked, ok := req.Context().Value(idempotentContextKey{}).(bool); ok && marked {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry decides whether a failed attempt can be repeated. 429 means the
+// request was rejected before being processed, and a failure to connect means
+// it never reached the API, so both are safe for any method. An attempt timing
+// out is retried like any other network error, the caller checks that the
+// operation itself has not timed out.
+func shouldRetry(res *http.Response, err error, idempotent bool) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return false
+		}
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return idempotent
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return idempotent && res.StatusCode >= 500
+}
+
+// backoff returns the wait before the next attempt, honouring Retry-After
+// when the API sends one.
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			wait := time.Duration(seconds) * time.Second
+			if wait > t.maxWait {
+				return t.maxWait
+			}
+			return wait
+		}
+	}
+
+	ceiling := t.minWait << uint(attempt)
+	if ceiling <= 0 || ceiling > t.maxWait {
+		ceiling = t.maxWait
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+func statusOf(res *http.Response) int {
+	if res == nil {
+		return 0
+	}
+	return res.StatusCode
+}
+
+func errorOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
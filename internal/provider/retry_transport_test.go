@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// stubTransport answers every attempt with the next status of statuses, or
+// err when set, and records the bodies it received.
+type stubTransport struct {
+	statuses []int
+	err      error
+	attempts int
+	bodies   []string
+}
+
+func (s *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.attempts++
+	if req.Body != nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		s.bodies = append(s.bodies, string(body))
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	status := s.statuses[len(s.statuses)-1]
+	if s.attempts <= len(s.statuses) {
+		status = s.statuses[s.attempts-1]
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}, nil
+}
+
+func TestRetryTransport(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	resetErr := &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}
+
+	testCases := map[string]struct {
+		method       string
+		idempotent   bool
+		statuses     []int
+		err          error
+		wantAttempts int
+	}{
+		"get retried on 502":              {method: http.MethodGet, statuses: []int{502, 502, 200}, wantAttempts: 3},
+		"get gives up after max retries":  {method: http.MethodGet, statuses: []int{500}, wantAttempts: 4},
+		"get not retried on 404":          {method: http.MethodGet, statuses: []int{404}, wantAttempts: 1},
+		"post not retried on 502":         {method: http.MethodPost, statuses: []int{502, 200}, wantAttempts: 1},
+		"post retried on 429":             {method: http.MethodPost, statuses: []int{429, 200}, wantAttempts: 2},
+		"post retried on dial error":      {method: http.MethodPost, err: dialErr, wantAttempts: 4},
+		"post not retried on reset":       {method: http.MethodPost, err: resetErr, wantAttempts: 1},
+		"marked post retried on 502":      {method: http.MethodPost, idempotent: true, statuses: []int{502, 200}, wantAttempts: 2},
+		"delete retried on network error": {method: http.MethodDelete, err: resetErr, wantAttempts: 4},
+	}
+
+	for name, testCase := range testCases {
+		stub := &stubTransport{statuses: testCase.statuses, err: testCase.err}
+		transport := &retryTransport{next: stub, maxRetries: 3, minWait: time.Millisecond, maxWait: 5 * time.Millisecond}
+
+		ctx := context.Background()
+		if testCase.idempotent {
+			ctx = withIdempotentRetries(ctx)
+		}
+		req, _ := http.NewRequestWithContext(ctx, testCase.method, "https://api.bitrise.io/v0.1/apps", bytes.NewReader([]byte(`{"title":"app"}`)))
+
+		res, _ := transport.RoundTrip(req)
+		if res != nil {
+			res.Body.Close()
+		}
+		if stub.attempts != testCase.wantAttempts {
+			t.Errorf("%s: expected %d attempts, got %d", name, testCase.wantAttempts, stub.attempts)
+		}
+		for _, body := range stub.bodies {
+			if body != `{"title":"app"}` {
+				t.Errorf("%s: expected the body to be resent on every attempt, got %q", name, body)
+			}
+		}
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := &retryTransport{minWait: time.Second, maxWait: 10 * time.Second}
+
+	for attempt := 0; attempt < 10; attempt++ {
+		if wait := transport.backoff(attempt, nil); wait < 0 || wait > 10*time.Second {
+			t.Errorf("attempt %d: wait %s outside of [0, 10s]", attempt, wait)
+		}
+	}
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"4"}}}
+	if wait := transport.backoff(0, res); wait != 4*time.Second {
+		t.Errorf("expected Retry-After to be honoured, got %s", wait)
+	}
+	res.Header.Set("Retry-After", "120")
+	if wait := transport.backoff(0, res); wait != 10*time.Second {
+		t.Errorf("expected Retry-After to be capped, got %s", wait)
+	}
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const (
+	// testAccAppPrefix starts the title of every app created by acceptance
+	// tests against a real workspace, so sweepers can clean up after them.
+	testAccAppPrefix = "tf-acc-"
+	// testAccSecretPrefix starts the name of every test secret.
+	testAccSecretPrefix = "TF_ACC_"
+)
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("bitrise_app", &resource.Sweeper{
+		Name: "bitrise_app",
+		F:    sweepApps,
+		Dependencies: []string{
+			"tf_acc_app_secrets",
+			"tf_acc_app_webhooks",
+		},
+	})
+
+	// Secrets and webhooks are not resources of the provider: these sweepers
+	// clean up what tests leave on apps which are kept, such as shared ones.
+	resource.AddTestSweepers("tf_acc_app_secrets", &resource.Sweeper{
+		Name: "tf_acc_app_secrets",
+		F:    sweepAppSecrets,
+	})
+
+	resource.AddTestSweepers("tf_acc_app_webhooks", &resource.Sweeper{
+		Name: "tf_acc_app_webhooks",
+		F:    sweepAppWebhooks,
+	})
+}
+
+type sweeperSecret struct {
+	Name string `json:"name"`
+}
+
+type sweeperWebhook struct {
+	Slug string `json:"slug"`
+	Url  string `json:"url"`
+}
+
+// sweeperClient configures a client like the provider does, from the
+// `BITRISE_TOKEN` and `BITRISE_API_URL` environment variables. The region
+// given to `-sweep` is ignored since Bitrise has none.
+func sweeperClient() (*BitriseClient, error) {
+	token := os.Getenv("BITRISE_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("BITRISE_TOKEN must be set to run sweepers")
+	}
+	endpoint := defaultEndpoint
+	if env := os.Getenv("BITRISE_API_URL"); env != "" {
+		endpoint = env
+	}
+	return &BitriseClient{
+		HTTPClient:     &http.Client{Transport: newAPITransport(http.DefaultTransport, defaultMaxRetries, defaultRetryMaxWait, defaultRequestTimeout)},
+		Endpoint:       endpoint,
+		Token:          token,
+		RequestTimeout: defaultRequestTimeout,
+	}, nil
+}
+
+func sweepApps(region string) error {
+	ctx := context.Background()
+	client, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+
+	apps, err := listApps(ctx, client, "/apps", url.Values{"title": []string{testAccAppPrefix}})
+	if err != nil {
+		return fmt.Errorf("unable to list apps: %w", err)
+	}
+	for _, app := range apps {
+		if !strings.HasPrefix(app.Title, testAccAppPrefix) {
+			continue
+		}
+		log.Printf("[INFO] Deleting app %s (%s)", app.Title, app.Slug)
+		err := client.do(ctx, http.MethodDelete, "/apps/"+url.PathEscape(app.Slug), nil, nil, nil)
+		if err != nil && !IsNotFound(err) {
+			return fmt.Errorf("unable to delete app %s: %w", app.Slug, err)
+		}
+	}
+	return nil
+}
+
+// sweepAppSecrets removes the test secrets left on any app, as tests add
+// secrets to apps which are not test apps themselves.
+func sweepAppSecrets(region string) error {
+	ctx := context.Background()
+	client, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+
+	apps, err := listApps(ctx, client, "/apps", nil)
+	if err != nil {
+		return fmt.Errorf("unable to list apps: %w", err)
+	}
+	for _, app := range apps {
+		secretsPath := "/apps/" + url.PathEscape(app.Slug) + "/secrets"
+		var respStruct struct {
+			Data []sweeperSecret `json:"data"`
+		}
+		if err := client.do(ctx, http.MethodGet, secretsPath, nil, nil, &respStruct); err != nil {
+			return fmt.Errorf("unable to list secrets of app %s: %w", app.Slug, err)
+		}
+		for _, secret := range respStruct.Data {
+			if !strings.HasPrefix(secret.Name, testAccSecretPrefix) {
+				continue
+			}
+			log.Printf("[INFO] Deleting secret %s of app %s", secret.Name, app.Slug)
+			err := client.do(ctx, http.MethodDelete, secretsPath+"/"+url.PathEscape(secret.Name), nil, nil, nil)
+			if err != nil && !IsNotFound(err) {
+				return fmt.Errorf("unable to delete secret %s of app %s: %w", secret.Name, app.Slug, err)
+			}
+		}
+	}
+	return nil
+}
+
+// sweepAppWebhooks removes the outgoing webhooks of any app whose URL carries
+// the test prefix, as webhooks have no title.
+func sweepAppWebhooks(region string) error {
+	ctx := context.Background()
+	client, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+
+	apps, err := listApps(ctx, client, "/apps", nil)
+	if err != nil {
+		return fmt.Errorf("unable to list apps: %w", err)
+	}
+	for _, app := range apps {
+		webhooksPath := "/apps/" + url.PathEscape(app.Slug) + "/outgoing-webhooks"
+		var respStruct struct {
+			Data []sweeperWebhook `json:"data"`
+		}
+		if err := client.do(ctx, http.MethodGet, webhooksPath, nil, nil, &respStruct); err != nil {
+			return fmt.Errorf("unable to list webhooks of app %s: %w", app.Slug, err)
+		}
+		for _, webhook := range respStruct.Data {
+			if !strings.Contains(webhook.Url, testAccAppPrefix) {
+				continue
+			}
+			log.Printf("[INFO] Deleting webhook %s of app %s", webhook.Slug, app.Slug)
+			err := client.do(ctx, http.MethodDelete, webhooksPath+"/"+url.PathEscape(webhook.Slug), nil, nil, nil)
+			if err != nil && !IsNotFound(err) {
+				return fmt.Errorf("unable to delete webhook %s of app %s: %w", webhook.Slug, app.Slug, err)
+			}
+		}
+	}
+	return nil
+}
+
+func TestSweepers(t *testing.T) {
+	fake := newFakeBitrise(t)
+	t.Setenv("BITRISE_API_URL", fake.server.URL)
+	t.Setenv("BITRISE_TOKEN", fakeBitriseToken)
+
+	kept := fake.AddApp(App{Title: "mobile", Owner: AppOwner{Slug: fakeOrgSlug}})
+	swept := []*App{
+		fake.AddApp(App{Title: testAccAppPrefix + "one", Owner: AppOwner{Slug: fakeOrgSlug}}),
+		fake.AddApp(App{Title: testAccAppPrefix + "two", Owner: AppOwner{Slug: fakeOtherOrgSlug}}),
+		fake.AddApp(App{Title: testAccAppPrefix + "three", Owner: AppOwner{Slug: fakeOrgSlug}}),
+	}
+	// Tests leave secrets and webhooks on apps which are kept too.
+	for _, app := range []*App{kept, swept[0]} {
+		fake.AddSecret(app.Slug, "API_KEY")
+		fake.AddSecret(app.Slug, testAccSecretPrefix+"API_KEY")
+		fake.AddWebhook(app.Slug, "https://hooks.example.com/deploy")
+		fake.AddWebhook(app.Slug, "https://hooks.example.com/"+testAccAppPrefix+"deploy")
+	}
+
+	for _, sweep := range []func(string) error{sweepAppSecrets, sweepAppWebhooks} {
+		if err := sweep("all"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	for _, app := range []*App{kept, swept[0]} {
+		if secrets := fake.SecretNames(app.Slug); len(secrets) != 1 || secrets[0] != "API_KEY" {
+			t.Errorf("expected only the secret API_KEY to be kept on app %s, got %v", app.Title, secrets)
+		}
+		if webhooks := fake.WebhookUrls(app.Slug); len(webhooks) != 1 || webhooks[0] != "https://hooks.example.com/deploy" {
+			t.Errorf("expected only the deploy webhook to be kept on app %s, got %v", app.Title, webhooks)
+		}
+	}
+
+	if err := sweepApps("all"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if fake.App(kept.Slug) == nil {
+		t.Errorf("expected app %s to be kept", kept.Title)
+	}
+	for _, app := range swept {
+		if fake.App(app.Slug) != nil {
+			t.Errorf("expected app %s to be swept", app.Title)
+		}
+	}
+}
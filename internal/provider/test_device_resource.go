@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// udidRegex matches the UDIDs Apple accepts for ad-hoc provisioning: the 40
+// hex digits of older devices, the 8-16 hex digits of devices since the
+// iPhone XS, and the hardware UUID of Macs.
+var udidRegex = regexp.MustCompile(`^([0-9a-fA-F]{40}|[0-9a-fA-F]{8}-[0-9a-fA-F]{16}|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})$`)
+
+// testDeviceTypes are the device types of registered test devices.
+var testDeviceTypes = []string{"ios", "tvos", "watchos", "macos"}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TestDeviceResource{}
+var _ resource.ResourceWithImportState = &TestDeviceResource{}
+
+func NewTestDeviceResource() resource.Resource {
+	return &TestDeviceResource{}
+}
+
+// TestDeviceResource defines the resource implementation.
+type TestDeviceResource struct {
+	client *BitriseClient
+}
+
+// TestDevice is a device registered for ad-hoc distribution.
+type TestDevice struct {
+	Slug       string `json:"slug"`
+	DeviceId   string `json:"device_id"`
+	DeviceType string `json:"device_type"`
+	Name       string `json:"name"`
+	Owner      string `json:"owner"`
+}
+
+type testDeviceResponse struct {
+	Data TestDevice `json:"data"`
+}
+
+type testDeviceListResponse struct {
+	Data   []TestDevice `json:"data"`
+	Paging Paging       `json:"paging"`
+}
+
+type testDeviceParams struct {
+	DeviceId   string `json:"device_id,omitempty"`
+	DeviceType string `json:"device_type,omitempty"`
+	Name       string `json:"name"`
+}
+
+// TestDeviceResourceModel describes the resource data model.
+type TestDeviceResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	OrganizationSlug types.String `tfsdk:"organization_slug"`
+	Udid             types.String `tfsdk:"udid"`
+	DeviceType       types.String `tfsdk:"device_type"`
+	Name             types.String `tfsdk:"name"`
+	Owner            types.String `tfsdk:"owner"`
+}
+
+func (r *TestDeviceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_test_device"
+}
+
+func (r *TestDeviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Registers a test device for ad-hoc distribution, for the user of the token or for an organization. Import with `<slug>`, or `<organization_slug>/<slug>` for organization devices.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the device",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_slug": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "SLUG of the organization to register the device for. Defaults to the user of the token.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"udid": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "UDID of the device",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(udidRegex, "must be a UDID of 40 hex digits, of the form 00008030-001A35E11A88003A, or a Mac hardware UUID"),
+				},
+			},
+			"device_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Type of the device, one of `" + strings.Join(testDeviceTypes, "`, `") + "`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(testDeviceTypes...),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the device",
+			},
+			"owner": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Username of the user, or name of the organization, owning the device",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *TestDeviceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TestDeviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *TestDeviceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := testDeviceResponse{}
+	params := testDeviceParams{
+		DeviceId:   data.Udid.ValueString(),
+		DeviceType: data.DeviceType.ValueString(),
+		Name:       data.Name.ValueString(),
+	}
+	err := r.client.do(ctx, http.MethodPost, testDevicesPath(data.OrganizationSlug.ValueString()), nil, params, &respStruct)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to register test device, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(respStruct.Data.Slug)
+	data.Owner = types.StringValue(respStruct.Data.Owner)
+
+	tflog.Trace(ctx, "registered a test device")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TestDeviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *TestDeviceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := testDeviceResponse{}
+	devicePath := testDevicesPath(data.OrganizationSlug.ValueString()) + "/" + url.PathEscape(data.Id.ValueString())
+	err := r.client.do(ctx, http.MethodGet, devicePath, nil, nil, &respStruct)
+	if IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read test device, got error: %s", err))
+		return
+	}
+
+	data.Udid = types.StringValue(respStruct.Data.DeviceId)
+	data.DeviceType = types.StringValue(respStruct.Data.DeviceType)
+	data.Name = types.StringValue(respStruct.Data.Name)
+	data.Owner = types.StringValue(respStruct.Data.Owner)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TestDeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *TestDeviceResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respStruct := testDeviceResponse{}
+	devicePath := testDevicesPath(data.OrganizationSlug.ValueString()) + "/" + url.PathEscape(data.Id.ValueString())
+	err := r.client.do(ctx, http.MethodPatch, devicePath, nil, testDeviceParams{Name: data.Name.ValueString()}, &respStruct)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update test device, got error: %s", err))
+		return
+	}
+	data.Name = types.StringValue(respStruct.Data.Name)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TestDeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *TestDeviceResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devicePath := testDevicesPath(data.OrganizationSlug.ValueString()) + "/" + url.PathEscape(data.Id.ValueString())
+	err := r.client.do(ctx, http.MethodDelete, devicePath, nil, nil, nil)
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete test device, got error: %s", err))
+		return
+	}
+}
+
+func (r *TestDeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if !strings.Contains(req.ID, "/") {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	parts, err := parseImportID(req.ID, "organization_slug", "slug")
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_slug"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// testDevicesPath returns the devices of the organization, or of the user of
+// the token when orgSlug is empty.
+func testDevicesPath(orgSlug string) string {
+	if orgSlug == "" {
+		return "/me/test-devices"
+	}
+	return "/organizations/" + url.PathEscape(orgSlug) + "/test-devices"
+}
+
+// listTestDevices returns every device of the organization, or of the user of
+// the token when orgSlug is empty, following the `next` cursor of the API.
+func listTestDevices(ctx context.Context, client *BitriseClient, orgSlug string) ([]TestDevice, error) {
+	var devices []TestDevice
+	params := url.Values{}
+	for {
+		respStruct := testDeviceListResponse{}
+		err := client.do(ctx, http.MethodGet, testDevicesPath(orgSlug), params, nil, &respStruct)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, respStruct.Data...)
+		if respStruct.Paging.Next == "" {
+			return devices, nil
+		}
+		params.Set("next", respStruct.Paging.Next)
+	}
+}
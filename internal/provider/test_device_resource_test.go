@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestUdidRegex(t *testing.T) {
+	for udid, want := range map[string]bool{
+		"0123456789abcdef0123456789ABCDEF01234567": true,
+		"00008030-001A35E11A88003A":                true,
+		"8A3E2C4B-1F5D-4E6A-9B7C-0D1E2F3A4B5C":     true,
+		"0123456789abcdef0123456789abcdef0123456":  false,
+		"00008030001A35E11A88003A":                 false,
+		"00008030-001A35E11A88003Z":                false,
+		"":                                         false,
+	} {
+		if got := udidRegex.MatchString(udid); got != want {
+			t.Errorf("expected match of %q to be %t", udid, want)
+		}
+	}
+}
+
+func TestAccTestDeviceResource(t *testing.T) {
+	fake := newFakeBitrise(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if devices := fake.Devices(""); len(devices) != 0 {
+				return fmt.Errorf("expected no device of the user, got %d", len(devices))
+			}
+			if devices := fake.Devices(fakeOrgSlug); len(devices) != 0 {
+				return fmt.Errorf("expected no device of the organization, got %d", len(devices))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			// Invalid UDID
+			{
+				Config: fake.providerConfig() + `
+resource "bitrise_test_device" "user" {
+  udid        = "not-a-udid"
+  device_type = "ios"
+  name        = "Nate's iPhone"
+}
+`,
+				ExpectError: regexp.MustCompile("must be a UDID"),
+			},
+			// Create and Read testing
+			{
+				Config: fake.providerConfig() + testAccTestDeviceResourceConfig("Nate's iPhone"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("bitrise_test_device.user", "id"),
+					resource.TestCheckResourceAttr("bitrise_test_device.user", "owner", "nate"),
+					resource.TestCheckResourceAttr("bitrise_test_device.organization", "owner", "PG Mobile"),
+					func(s *terraform.State) error {
+						if devices := fake.Devices(fakeOrgSlug); len(devices) != 1 || devices[0].DeviceType != "tvos" {
+							return fmt.Errorf("expected the Apple TV to be registered for the organization, got %v", devices)
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "bitrise_test_device.user",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName: "bitrise_test_device.organization",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fakeOrgSlug + "/" + s.RootModule().Resources["bitrise_test_device.organization"].Primary.ID, nil
+				},
+				ImportStateVerify: true,
+			},
+			// Rename in place
+			{
+				Config: fake.providerConfig() + testAccTestDeviceResourceConfig("Nate's old iPhone"),
+				Check: func(s *terraform.State) error {
+					if devices := fake.Devices(""); len(devices) != 1 || devices[0].Name != "Nate's old iPhone" {
+						return fmt.Errorf("expected the device to be renamed, got %v", devices)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccTestDeviceResourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "bitrise_test_device" "user" {
+  udid        = "00008030-001A35E11A88003A"
+  device_type = "ios"
+  name        = %[1]q
+}
+
+resource "bitrise_test_device" "organization" {
+  organization_slug = %[2]q
+  udid              = "0123456789abcdef0123456789abcdef01234567"
+  device_type       = "tvos"
+  name              = "Lobby Apple TV"
+}
+`, name, fakeOrgSlug)
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TestDevicesDataSource{}
+
+func NewTestDevicesDataSource() datasource.DataSource {
+	return &TestDevicesDataSource{}
+}
+
+// TestDevicesDataSource defines the data source implementation.
+type TestDevicesDataSource struct {
+	client *BitriseClient
+}
+
+// TestDevicesDataSourceModel describes the data source data model.
+type TestDevicesDataSourceModel struct {
+	Id               types.String           `tfsdk:"id"`
+	OrganizationSlug types.String           `tfsdk:"organization_slug"`
+	Devices          []TestDevicesItemModel `tfsdk:"devices"`
+}
+
+// TestDevicesItemModel describes a single device of the test devices data
+// source.
+type TestDevicesItemModel struct {
+	Slug       types.String `tfsdk:"slug"`
+	Udid       types.String `tfsdk:"udid"`
+	DeviceType types.String `tfsdk:"device_type"`
+	Name       types.String `tfsdk:"name"`
+	Owner      types.String `tfsdk:"owner"`
+}
+
+func (d *TestDevicesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_test_devices"
+}
+
+func (d *TestDevicesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the test devices registered for the user of the token or for an organization.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SLUG of the organization, or `me` for the devices of the user",
+			},
+			"organization_slug": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "SLUG of the organization. Defaults to the user of the token.",
+			},
+			"devices": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Registered devices",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"slug": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SLUG of the device",
+						},
+						"udid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "UDID of the device",
+						},
+						"device_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the device",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the device",
+						},
+						"owner": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Username of the user, or name of the organization, owning the device",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TestDevicesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*BitriseClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *BitriseClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TestDevicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TestDevicesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	devices, err := listTestDevices(ctx, d.client, data.OrganizationSlug.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list test devices, got error: %s", err))
+		return
+	}
+
+	data.Devices = []TestDevicesItemModel{}
+	for _, device := range devices {
+		data.Devices = append(data.Devices, TestDevicesItemModel{
+			Slug:       types.StringValue(device.Slug),
+			Udid:       types.StringValue(device.DeviceId),
+			DeviceType: types.StringValue(device.DeviceType),
+			Name:       types.StringValue(device.Name),
+			Owner:      types.StringValue(device.Owner),
+		})
+	}
+	data.Id = types.StringValue("me")
+	if !data.OrganizationSlug.IsNull() {
+		data.Id = types.StringValue(data.OrganizationSlug.ValueString())
+	}
+
+	tflog.Trace(ctx, "read a test devices data source", map[string]interface{}{"count": len(data.Devices)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
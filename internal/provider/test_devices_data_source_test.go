@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTestDevicesDataSource(t *testing.T) {
+	fake := newFakeBitrise(t)
+	fake.AddDevice("", TestDevice{DeviceId: "00008030-001A35E11A88003A", DeviceType: "ios", Name: "Nate's iPhone"})
+	for i := 0; i < 3; i++ {
+		fake.AddDevice(fakeOrgSlug, TestDevice{
+			DeviceId: fmt.Sprintf("0123456789abcdef0123456789abcdef0123456%d", i), DeviceType: "ios", Name: fmt.Sprintf("QA iPhone %d", i),
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fake.providerConfig() + fmt.Sprintf(`
+data "bitrise_test_devices" "user" {}
+
+data "bitrise_test_devices" "organization" {
+  organization_slug = %q
+}
+`, fakeOrgSlug),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bitrise_test_devices.user", "id", "me"),
+					resource.TestCheckResourceAttr("data.bitrise_test_devices.user", "devices.#", "1"),
+					resource.TestCheckResourceAttr("data.bitrise_test_devices.user", "devices.0.udid", "00008030-001A35E11A88003A"),
+					resource.TestCheckResourceAttr("data.bitrise_test_devices.user", "devices.0.owner", "nate"),
+					resource.TestCheckResourceAttr("data.bitrise_test_devices.organization", "devices.#", "3"),
+					resource.TestCheckResourceAttr("data.bitrise_test_devices.organization", "devices.2.name", "QA iPhone 2"),
+					resource.TestCheckResourceAttr("data.bitrise_test_devices.organization", "devices.2.owner", "PG Mobile"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimeoutTransport(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first attempt hangs, the second one answers.
+		if atomic.AddInt32(&requests, 1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRetryTransport(newTimeoutTransport(http.DefaultTransport, 100*time.Millisecond), 1, time.Millisecond)}
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the timed out attempt to be retried, got: %s", err)
+	}
+	defer res.Body.Close()
+	// The attempt context stays alive until the body is closed.
+	time.Sleep(150 * time.Millisecond)
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil || string(body) != "ok" {
+		t.Errorf("expected body %q, got %q (%v)", "ok", body, err)
+	}
+}
+
+// TestTimeoutTransportRateLimitWait checks that waiting for the rate limit
+// window does not count against the timeout of an attempt.
+func TestTimeoutTransportRateLimitWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newAPITransport(http.DefaultTransport, 0, time.Second, 200*time.Millisecond)}
+	for i := 0; i < 2; i++ {
+		res, err := client.Get(server.URL)
+		if errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("request %d timed out while waiting for the rate limit window", i+1)
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		res.Body.Close()
+	}
+}